@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const diPath = "github.com/thinkdata-works/godi/pkg/di"
+
+// generate returns the source of the proxies for the named interfaces of the package.
+func generate(pkg *types.Package, names []string) ([]byte, error) {
+	if pkg.Path() == diPath {
+		return nil, fmt.Errorf("cannot generate proxies inside the di package")
+	}
+
+	imports := newImports(pkg)
+	var body bytes.Buffer
+	for _, name := range names {
+		if err := writeProxy(&body, pkg, strings.TrimSpace(name), imports); err != nil {
+			return nil, err
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by diproxy. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", pkg.Name())
+	fmt.Fprintf(&src, "import (\n")
+	for _, path := range imports.sorted() {
+		if name := imports.names[path]; name != path[strings.LastIndex(path, "/")+1:] {
+			fmt.Fprintf(&src, "\t%s %q\n", name, path)
+		} else {
+			fmt.Fprintf(&src, "\t%q\n", path)
+		}
+	}
+	fmt.Fprintf(&src, ")\n")
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated source: %w", err)
+	}
+	return formatted, nil
+}
+
+// writeProxy writes the proxy of an interface along with the init function registering it.
+func writeProxy(buf *bytes.Buffer, pkg *types.Package, name string, imports *imports) error {
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		return fmt.Errorf("type %s is not declared in package %s", name, pkg.Path())
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || !types.IsInterface(named) {
+		return fmt.Errorf("type %s is not an interface", name)
+	}
+	if named.TypeParams().Len() > 0 {
+		return fmt.Errorf("type %s is generic, generic interfaces cannot be proxied", name)
+	}
+	iface := named.Underlying().(*types.Interface)
+	if !iface.IsMethodSet() {
+		return fmt.Errorf("type %s is a constraint, not an interface", name)
+	}
+
+	proxy := lowerFirst(name) + "Proxy"
+	di := imports.name(diPath)
+
+	fmt.Fprintf(buf, "\nfunc init() {\n")
+	fmt.Fprintf(buf, "\t%s.RegisterProxy(func(invoker *%s.Invoker) %s {\n", di, di, name)
+	fmt.Fprintf(buf, "\t\treturn &%s{invoker: invoker}\n", proxy)
+	fmt.Fprintf(buf, "\t})\n}\n\n")
+
+	fmt.Fprintf(buf, "// %s forwards the method calls of %s to the interceptors of the instance.\n", proxy, name)
+	fmt.Fprintf(buf, "type %s struct {\n\tinvoker *%s.Invoker\n}\n", proxy, di)
+
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		if !method.Exported() {
+			// reflection cannot call unexported methods
+			return fmt.Errorf("type %s has the unexported method %s, it cannot be intercepted", name, method.Name())
+		}
+		writeMethod(buf, proxy, method, imports)
+	}
+	return nil
+}
+
+// writeMethod writes a proxy method forwarding its arguments to the invoker and converting back the results.
+func writeMethod(buf *bytes.Buffer, proxy string, method *types.Func, imports *imports) {
+	sig := method.Type().(*types.Signature)
+
+	// reflect is only imported when a method has arguments to forward
+	var reflect string
+	if sig.Params().Len() > 0 {
+		reflect = imports.name("reflect")
+	}
+
+	var params, args []string
+	for i := 0; i < sig.Params().Len(); i++ {
+		typ := sig.Params().At(i).Type()
+		typeString := types.TypeString(typ, imports.qualifier)
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			typeString = "..." + types.TypeString(typ.(*types.Slice).Elem(), imports.qualifier)
+		}
+
+		params = append(params, fmt.Sprintf("a%d %s", i, typeString))
+		args = append(args, fmt.Sprintf(", %s.ValueOf(&a%d).Elem()", reflect, i))
+	}
+
+	var results []string
+	for i := 0; i < sig.Results().Len(); i++ {
+		results = append(results, types.TypeString(sig.Results().At(i).Type(), imports.qualifier))
+	}
+
+	fmt.Fprintf(buf, "\nfunc (p *%s) %s(%s)", proxy, method.Name(), strings.Join(params, ", "))
+	switch len(results) {
+	case 0:
+	case 1:
+		fmt.Fprintf(buf, " %s", results[0])
+	default:
+		fmt.Fprintf(buf, " (%s)", strings.Join(results, ", "))
+	}
+	fmt.Fprintf(buf, " {\n")
+
+	invoke := fmt.Sprintf("p.invoker.Invoke(%q%s)", method.Name(), strings.Join(args, ""))
+	if len(results) == 0 {
+		fmt.Fprintf(buf, "\t%s\n}\n", invoke)
+		return
+	}
+
+	fmt.Fprintf(buf, "\tout := %s\n", invoke)
+	var returned []string
+	for i, result := range results {
+		// a type assertion keeps nil results of interface types from panicking
+		fmt.Fprintf(buf, "\tr%d, _ := out[%d].Interface().(%s)\n", i, i, result)
+		returned = append(returned, fmt.Sprintf("r%d", i))
+	}
+	fmt.Fprintf(buf, "\treturn %s\n}\n", strings.Join(returned, ", "))
+}
+
+// imports tracks the packages referenced by the generated source and the names they are imported under.
+type imports struct {
+	pkg   *types.Package
+	names map[string]string // import path to name
+	used  map[string]bool   // names in use
+}
+
+func newImports(pkg *types.Package) *imports {
+	// the receiver and the results of the proxy methods are not shadowed by package names
+	imports := &imports{pkg: pkg, names: map[string]string{}, used: map[string]bool{"p": true, "out": true}}
+	imports.name(diPath)
+	return imports
+}
+
+// name returns the name the package is imported under, importing it if needed.
+func (i *imports) name(path string) string {
+	base := path[strings.LastIndex(path, "/")+1:]
+	if path == diPath {
+		base = "di"
+	}
+	return i.add(path, base)
+}
+
+// qualifier qualifies the types of other packages by the name they are imported under.
+func (i *imports) qualifier(pkg *types.Package) string {
+	if pkg == i.pkg {
+		return ""
+	}
+	return i.add(pkg.Path(), pkg.Name())
+}
+
+// add imports the package under its name, or under a numbered name if the name is already taken.
+func (i *imports) add(path string, base string) string {
+	if name, exist := i.names[path]; exist {
+		return name
+	}
+
+	name := base
+	for n := 2; i.used[name] || i.pkg.Scope().Lookup(name) != nil; n++ {
+		name = fmt.Sprintf("%s%d", base, n)
+	}
+	i.names[path] = name
+	i.used[name] = true
+	return name
+}
+
+// sorted returns the imported paths in order.
+func (i *imports) sorted() []string {
+	paths := make([]string, 0, len(i.names))
+	for path := range i.names {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// lowerFirst lowers the first letter of the name.
+func lowerFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
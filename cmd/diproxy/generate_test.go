@@ -0,0 +1,100 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const repositorySource = `package store
+
+import (
+	"context"
+	"io"
+)
+
+type User struct{}
+
+type UserRepository interface {
+	io.Closer
+	Find(ctx context.Context, id int) (*User, error)
+	Tags(prefix string, ids ...int) []string
+	Ping()
+}
+
+type Store interface {
+	UserRepository
+	Flush() error
+}
+
+type Pinger interface {
+	Ping() error
+}
+
+type NotAnInterface struct{}
+
+type hidden interface {
+	find() *User
+}
+`
+
+func check(t *testing.T, fset *token.FileSet, sources ...string) (*types.Package, error) {
+	var files []*ast.File
+	for _, src := range sources {
+		file, err := parser.ParseFile(fset, "", src, 0)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		files = append(files, file)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check("example.com/store", fset, files, nil)
+}
+
+func TestGenerate(t *testing.T) {
+	fset := token.NewFileSet()
+	pkg, err := check(t, fset, repositorySource)
+	assert.NoError(t, err)
+
+	src, err := generate(pkg, []string{"UserRepository", " Store"})
+	assert.NoError(t, err)
+
+	generated := string(src)
+	assert.Contains(t, generated, "// Code generated by diproxy. DO NOT EDIT.")
+	assert.Contains(t, generated, "di.RegisterProxy(func(invoker *di.Invoker) UserRepository {")
+	assert.Contains(t, generated, "func (p *userRepositoryProxy) Find(a0 context.Context, a1 int) (*User, error) {")
+	assert.Contains(t, generated, "func (p *userRepositoryProxy) Tags(a0 string, a1 ...int) []string {")
+	assert.Contains(t, generated, "func (p *storeProxy) Close() error {")
+	assert.Contains(t, generated, "func (p *storeProxy) Flush() error {")
+
+	// the proxies implement the interfaces
+	_, err = check(t, token.NewFileSet(), repositorySource, generated)
+	assert.NoError(t, err)
+
+	// reflect is not imported when no method has arguments
+	src, err = generate(pkg, []string{"Pinger"})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(src), `"reflect"`)
+	_, err = check(t, token.NewFileSet(), repositorySource, string(src))
+	assert.NoError(t, err)
+}
+
+func TestGenerate_Fail(t *testing.T) {
+	pkg, err := check(t, token.NewFileSet(), repositorySource)
+	assert.NoError(t, err)
+
+	_, err = generate(pkg, []string{"Missing"})
+	assert.ErrorContains(t, err, "is not declared")
+
+	_, err = generate(pkg, []string{"NotAnInterface"})
+	assert.ErrorContains(t, err, "is not an interface")
+
+	_, err = generate(pkg, []string{"hidden"})
+	assert.ErrorContains(t, err, "unexported method find")
+}
@@ -0,0 +1,100 @@
+// Command diproxy generates the proxies used by di.Intercept to intercept the method calls of interfaces.
+//
+// It writes, for every interface given with -type, a proxy forwarding each method to a di.Invoker, and registers
+// it with di.RegisterProxy from an init function. It is usually run with go generate, next to the interfaces:
+//
+//	//go:generate go run github.com/thinkdata-works/godi/cmd/diproxy -type UserRepository,OrderRepository
+//
+// Usage:
+//
+//	diproxy -type T[,T...] [-output file] [dir]
+//
+// The proxies are written to <t>_proxy.go in the package directory, dir defaulting to the current directory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("diproxy: ")
+
+	typeNames := flag.String("type", "", "comma-separated list of interfaces to generate proxies for; required")
+	output := flag.String("output", "", "output file name; default <dir>/<type>_proxy.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: diproxy -type T[,T...] [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	names := strings.Split(*typeNames, ",")
+
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(names[0])+"_proxy.go")
+	}
+
+	pkg, err := loadPackage(dir, filepath.Base(*output))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(pkg, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadPackage parses and type-checks the package in the directory, leaving out the file being generated
+// so that a stale proxy does not prevent generating it again.
+func loadPackage(dir string, output string) (*types.Package, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		if name == output {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	path := buildPkg.ImportPath
+	if path == "" || path == "." {
+		path = buildPkg.Name
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check(path, fset, files, nil)
+}
@@ -0,0 +1,106 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BindingOption configures a binding at registration time.
+type BindingOption func(*binding)
+
+// Named registers the binding under a name, as the Named registration methods do.
+func Named(name string) BindingOption {
+	return func(b *binding) {
+		b.name = name
+	}
+}
+
+// AsSingleton registers the binding with a singleton lifetime. This is the default for Bind.
+func AsSingleton() BindingOption {
+	return func(b *binding) {
+		b.btype = Binding_Singleton
+	}
+}
+
+// AsInstance registers the binding with an instance lifetime, creating a new value for each injection.
+func AsInstance() BindingOption {
+	return func(b *binding) {
+		b.btype = Binding_Instance
+	}
+}
+
+// AsScoped registers the binding with a scoped lifetime, sharing a value within each scope.
+func AsScoped() BindingOption {
+	return func(b *binding) {
+		b.btype = Binding_Scoped
+	}
+}
+
+// Bind binds the Iface interface to the Impl implementation without a hand-written provider.
+// If Impl is already bound when Bind is called, its binding is used to construct the implementation.
+// Otherwise Impl must be a struct or a pointer to a struct, which is created with its zero value and
+// then has its tagged fields filled.
+// Bindings are singletons unless a lifetime option is given, and can be named with the Named option.
+// Impl, or a pointer to it, must implement Iface.
+func Bind[Iface any, Impl any](i *Injector, options ...BindingOption) {
+	ifaceType := reflect.TypeFor[Iface]()
+	implType := reflect.TypeFor[Impl]()
+
+	if i.isVerbose() {
+		i.logCall("Bind", fullyQualifiedTypeString(ifaceType), fullyQualifiedTypeString(implType))
+	}
+
+	provider, filled, err := i.implementationProvider(ifaceType, implType)
+	if err != nil {
+		i.handleError(err)
+		return
+	}
+
+	if filled != nil {
+		options = append(options[:len(options):len(options)], func(b *binding) {
+			b.impl = filled
+		})
+	}
+
+	err = i.bind(provider, "", Binding_Singleton, options)
+	if err != nil {
+		i.handleError(err)
+	}
+}
+
+// implementationProvider builds a provider function returning the interface type from an implementation type.
+// When the provider creates the implementation itself, the type it creates is returned along with it.
+func (injector *Injector) implementationProvider(ifaceType reflect.Type, implType reflect.Type) (interface{}, reflect.Type, error) {
+	if ifaceType.Kind() != reflect.Interface {
+		return nil, nil, injector.errorMiddleWare(&InvalidArgumentError{Type: ifaceType, Reason: "bindings can only be made to an interface type"})
+	}
+
+	// use the registered constructor of the implementation
+	if _, exist := injector.lookup(implType, ""); exist {
+		if !implType.Implements(ifaceType) {
+			return nil, nil, injector.errorMiddleWare(&InvalidArgumentError{Type: implType, Reason: fmt.Sprintf("does not implement `%s`", fullyQualifiedTypeString(ifaceType))})
+		}
+
+		providerType := reflect.FuncOf([]reflect.Type{implType}, []reflect.Type{ifaceType}, false)
+		return reflect.MakeFunc(providerType, func(args []reflect.Value) []reflect.Value {
+			return []reflect.Value{args[0].Convert(ifaceType)}
+		}).Interface(), nil, nil
+	}
+
+	structType := implType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, nil, injector.errorMiddleWare(&InvalidArgumentError{Type: implType, Reason: "must be bound already, or be a struct or a pointer to a struct"})
+	}
+	if !reflect.PointerTo(structType).Implements(ifaceType) {
+		return nil, nil, injector.errorMiddleWare(&InvalidArgumentError{Type: implType, Reason: fmt.Sprintf("does not implement `%s`", fullyQualifiedTypeString(ifaceType))})
+	}
+
+	// the tagged fields are filled once the provider returns
+	providerType := reflect.FuncOf(nil, []reflect.Type{ifaceType}, false)
+	return reflect.MakeFunc(providerType, func(args []reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.New(structType).Convert(ifaceType)}
+	}).Interface(), reflect.PointerTo(structType), nil
+}
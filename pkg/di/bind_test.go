@@ -0,0 +1,80 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Notifier interface {
+	Notify() string
+}
+
+type EmailNotifier struct {
+	DB      Database `di:"type"`
+	Address string
+}
+
+func (n *EmailNotifier) Notify() string {
+	return "email:" + n.Address
+}
+
+func TestInjector_Bind(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() Database {
+		return &MySQL{}
+	})
+
+	// zero value construction, with the tagged fields filled
+	Bind[Notifier, *EmailNotifier](injector)
+	notifier := Get[Notifier](injector)
+	assert.IsType(t, &EmailNotifier{}, notifier)
+	assert.NotNil(t, notifier.(*EmailNotifier).DB)
+	assert.Same(t, notifier, Get[Notifier](injector))
+
+	// struct implementations are constructed as pointers
+	Bind[Notifier, EmailNotifier](injector, Named("value"), AsInstance())
+	value := NamedGet[Notifier](injector, "value")
+	assert.IsType(t, &EmailNotifier{}, value)
+	assert.NotSame(t, value, NamedGet[Notifier](injector, "value"))
+
+	// registered constructors are used when the implementation is bound
+	injector.Instance(func(db Database) *EmailNotifier {
+		return &EmailNotifier{Address: "ops@example.com"}
+	})
+	Bind[Notifier, *EmailNotifier](injector, Named("ops"), AsScoped())
+	scope := injector.NewScope()
+	ops := NamedGet[Notifier](scope, "ops")
+	assert.Equal(t, "email:ops@example.com", ops.Notify())
+	assert.NotNil(t, ops.(*EmailNotifier).DB)
+	assert.Same(t, ops, NamedGet[Notifier](scope, "ops"))
+
+	assert.NoError(t, injector.Validate())
+}
+
+func TestInjector_Bind_Fail(t *testing.T) {
+	var injector = NewInjector()
+	errorCount := 0
+	injector.SetErrorHandler(func(err error) {
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		errorCount++
+	})
+
+	Bind[Notifier, *MySQL](injector)
+	assert.Equal(t, 1, errorCount)
+	Bind[*EmailNotifier, *EmailNotifier](injector)
+	assert.Equal(t, 2, errorCount)
+	Bind[Notifier, Notifier](injector)
+	assert.Equal(t, 3, errorCount)
+	injector.Singleton(func() Database {
+		return &MySQL{}
+	})
+	Bind[Notifier, Database](injector)
+	assert.Equal(t, 4, errorCount)
+
+	_, err := TryGet[Notifier](injector)
+	assert.ErrorIs(t, err, ErrMissingProvider)
+}
@@ -0,0 +1,131 @@
+package di
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests are most useful when run with the race detector: go test -race ./...
+
+func TestInjector_Concurrent_Registration_And_Resolution(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() *John {
+		return &John{Val: 42}
+	})
+	injector.Singleton(func() *Alice {
+		return &Alice{}
+	})
+	injector.Instance(func() *Bob {
+		return &Bob{}
+	})
+	injector.Singleton(func() Database {
+		return &MySQL{}
+	})
+
+	const workers = 8
+	const iterations = 50
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+
+		// register new bindings and override existing ones
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				name := fmt.Sprintf("plugin-%d-%d", w, i)
+				injector.NamedSingleton(name, func() Plugin {
+					return &NamedPlugin{name: name}
+				})
+				injector.NamedInstance(name, func() *John {
+					return &John{Val: i}
+				})
+				Bind[Notifier, *EmailNotifier](injector, Named(name), AsInstance())
+			}
+		}(w)
+
+		// resolve while bindings are being registered
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				alice := Get[*Alice](injector)
+				assert.Equal(t, 42, alice.Bob.John.Val)
+				assert.Same(t, alice, Get[*Alice](injector))
+
+				Get[[]Plugin](injector)
+				Get[map[string]*John](injector)
+
+				s := struct {
+					Bob *Bob `di:"type"`
+				}{}
+				injector.Fill(&s)
+				assert.Same(t, alice, s.Bob.Alice)
+
+				injector.Call(func(j *John) {
+					assert.Equal(t, 42, j.Val)
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, Get[[]Plugin](injector), workers*iterations)
+	assert.Len(t, Get[map[string]Notifier](injector), workers*iterations)
+	assert.NoError(t, injector.Validate())
+}
+
+func TestInjector_Concurrent_Singleton_Creation(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+
+	var mu sync.Mutex
+	created := 0
+	injector.Singleton(func() Database {
+		mu.Lock()
+		defer mu.Unlock()
+		created++
+		return &MySQL{}
+	})
+	injector.Scoped(func() *Tx {
+		return &Tx{}
+	})
+
+	scope := injector.NewScope()
+	var wg sync.WaitGroup
+	results := make([]Database, 32)
+	txs := make([]*Tx, 32)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = Get[Database](injector)
+			txs[i] = Get[*Tx](scope)
+		}(i)
+	}
+
+	// registration, resets and scopes do not race with resolution
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		other := injector.NewScope()
+		other.Singleton(func() *John {
+			return &John{}
+		})
+		other.Reset()
+	}()
+	wg.Wait()
+
+	assert.Equal(t, 1, created)
+	for i := range results {
+		assert.Same(t, results[0], results[i])
+		assert.Same(t, txs[0], txs[i])
+	}
+}
@@ -0,0 +1,105 @@
+package di
+
+import (
+	"context"
+	"reflect"
+)
+
+// contextType is the type of provider and function arguments receiving the context of the resolution.
+var contextType = reflect.TypeFor[context.Context]()
+
+// contextMutex is a mutex whose Lock can be abandoned once a context is done.
+type contextMutex chan struct{}
+
+func newContextMutex() contextMutex {
+	return make(contextMutex, 1)
+}
+
+func (m contextMutex) Lock() {
+	m <- struct{}{}
+}
+
+func (m contextMutex) Unlock() {
+	<-m
+}
+
+func (m contextMutex) TryLock() bool {
+	select {
+	case m <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// LockContext locks the mutex, or returns the error of the context if it is done first.
+func (m contextMutex) LockContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case m <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ResolveContext resolves like the TryResolve method, passing the context to the providers that take a
+// context.Context argument. The resolution is abandoned once the context is done, including while waiting for
+// another caller creating a singleton, and a *CanceledError wrapping the error of the context is returned.
+// A provider already running is not interrupted, it should watch the context itself.
+func (injector *Injector) ResolveContext(ctx context.Context, abstraction interface{}) error {
+	if injector.isVerbose() {
+		injector.logCall("ResolveContext", debugNameString(abstraction))
+	}
+
+	return injector.resolve(ctx, abstraction, "")
+}
+
+// NamedResolveContext resolves like the ResolveContext method but for named bindings.
+func (injector *Injector) NamedResolveContext(ctx context.Context, abstraction interface{}, name string) error {
+	if injector.isVerbose() {
+		injector.logCall("NamedResolveContext", debugNameString(abstraction))
+	}
+
+	return injector.resolve(ctx, abstraction, name)
+}
+
+// FillContext fills like the TryFill method, resolving the fields with the context as ResolveContext does.
+func (injector *Injector) FillContext(ctx context.Context, structure interface{}) error {
+	if injector.isVerbose() {
+		injector.logCall("FillContext", debugNameString(structure))
+	}
+
+	return injector.fillContext(ctx, structure)
+}
+
+// CallContext calls like the TryCall method, resolving the arguments with the context as ResolveContext does.
+// Arguments of the function of type context.Context receive the context.
+func (injector *Injector) CallContext(ctx context.Context, function interface{}) error {
+	if injector.isVerbose() {
+		injector.logCall("CallContext", debugTypeString(function))
+	}
+
+	return injector.call(ctx, function)
+}
+
+// GetContext gets like the TryGet function, resolving with the context as ResolveContext does.
+// The empty value of the type is returned alongside the error.
+func GetContext[Type any](ctx context.Context, i *Injector) (Type, error) {
+	return NamedGetContext[Type](ctx, i, "")
+}
+
+// NamedGetContext gets like the GetContext function but for named bindings.
+func NamedGetContext[Type any](ctx context.Context, i *Injector, name string) (Type, error) {
+	var empty Type
+
+	instance, err := i.get(ctx, reflect.TypeFor[Type](), name)
+	if err != nil {
+		return empty, err
+	}
+
+	return instance.(Type), nil
+}
@@ -0,0 +1,118 @@
+package di
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type requestKey struct{}
+
+type Request struct {
+	ID string
+}
+
+type RequestHandler struct {
+	Request *Request `di:"type"`
+}
+
+func TestInjector_ResolveContext(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Instance(func(ctx context.Context) *Request {
+		id, _ := ctx.Value(requestKey{}).(string)
+		return &Request{ID: id}
+	})
+
+	ctx := context.WithValue(context.Background(), requestKey{}, "42")
+
+	// providers taking a context receive the context of the resolution
+	var request *Request
+	assert.NoError(t, injector.ResolveContext(ctx, &request))
+	assert.Equal(t, "42", request.ID)
+
+	request, err := GetContext[*Request](ctx, injector)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", request.ID)
+
+	handler := &RequestHandler{}
+	assert.NoError(t, injector.FillContext(ctx, handler))
+	assert.Equal(t, "42", handler.Request.ID)
+
+	assert.NoError(t, injector.CallContext(ctx, func(ctx context.Context, request *Request) {
+		assert.Equal(t, "42", ctx.Value(requestKey{}))
+		assert.Equal(t, "42", request.ID)
+	}))
+
+	// resolutions without a context use the background context
+	assert.Equal(t, "", Get[*Request](injector).ID)
+
+	// the context is not a dependency to register
+	assert.NoError(t, injector.Validate())
+	assert.Empty(t, injector.Graph().Edges)
+}
+
+func TestInjector_ResolveContext_Canceled(t *testing.T) {
+	var injector = NewInjector()
+	invoked := false
+	injector.Singleton(func() *John {
+		invoked = true
+		return &John{}
+	})
+	injector.Singleton(func(john *John) *Alice {
+		return &Alice{}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := GetContext[*Alice](ctx, injector)
+	assert.ErrorIs(t, err, ErrCanceled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, invoked)
+
+	var canceled *CanceledError
+	assert.ErrorAs(t, err, &canceled)
+	assert.Equal(t, "*di.Alice", canceled.Type.String())
+	assert.Contains(t, canceled.Site, "context_test.go:")
+
+	var alice *Alice
+	assert.ErrorIs(t, injector.ResolveContext(ctx, &alice), context.Canceled)
+	assert.ErrorIs(t, injector.FillContext(ctx, &RequestHandler{}), ErrMissingProvider)
+	assert.ErrorIs(t, injector.CallContext(ctx, func(alice *Alice) {}), context.Canceled)
+}
+
+func TestInjector_ResolveContext_Singleton_Wait(t *testing.T) {
+	var injector = NewInjector()
+	started, release := make(chan struct{}), make(chan struct{})
+	injector.Singleton(func() *John {
+		close(started)
+		<-release
+		return &John{Val: 42}
+	})
+
+	done := make(chan *John)
+	go func() {
+		john, _ := GetContext[*John](context.Background(), injector)
+		done <- john
+	}()
+	<-started
+
+	// waiting for the singleton being created is abandoned once the deadline passes
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := GetContext[*John](ctx, injector)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	john := <-done
+	assert.Equal(t, 42, john.Val)
+
+	same, err := GetContext[*John](context.Background(), injector)
+	assert.NoError(t, err)
+	assert.Same(t, john, same)
+}
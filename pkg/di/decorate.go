@@ -0,0 +1,115 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+const eventDecorate = "decorate"
+
+// decorators holds the decorators of a binding. It is shared by the copies of the binding made for scopes,
+// and is carried over when the provider of the binding is replaced.
+type decorators struct {
+	mu   sync.RWMutex
+	list []func(interface{}) interface{}
+}
+
+// get returns the decorators in the order they were registered.
+func (d *decorators) get() []func(interface{}) interface{} {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.list
+}
+
+// copy returns a copy of the decorators that is not changed by decorators added later.
+func (d *decorators) copy() *decorators {
+	return &decorators{list: d.get()}
+}
+
+// Decorate registers a decorator for the unnamed binding of the type.
+// See DecorateNamed.
+func Decorate[Type any](i *Injector, decorator func(Type) Type) error {
+	return DecorateNamed[Type](i, "", decorator)
+}
+
+// DecorateNamed registers a decorator for the binding of the type registered under the name.
+// Every resolved instance is passed through the decorators of its binding in the order they were registered,
+// after its tagged fields are filled, and the decorated instance is injected instead. Singleton and scoped
+// instances are decorated once, before they are cached, while instance bindings are decorated on every resolution.
+// A singleton that has already been created is decorated immediately, so later resolutions return the decorated instance.
+// Only bindings registered in the injector itself can be decorated, not those inherited by a scope.
+// It returns a *MissingProviderError if nothing is bound, or a *FrozenError if the injector is frozen.
+func DecorateNamed[Type any](i *Injector, name string, decorator func(Type) Type) error {
+	typ := reflect.TypeFor[Type]()
+	if i.isVerbose() {
+		i.logCall("DecorateNamed", fullyQualifiedTypeString(typ), fmt.Sprintf("%q", name), debugTypeString(decorator))
+	}
+
+	if decorator == nil {
+		return i.errorMiddleWare(&InvalidArgumentError{Reason: "the decorator must not be nil"})
+	}
+
+	return i.addDecorator(typ, name, func(instance interface{}) interface{} {
+		return decorator(instance.(Type))
+	})
+}
+
+// addDecorator adds a decorator to a binding registered in the injector, and applies it to the singleton instance if it exists.
+func (injector *Injector) addDecorator(typ reflect.Type, name string, decorator func(interface{}) interface{}) error {
+	if injector.Frozen() {
+		return injector.errorMiddleWare(&FrozenError{Operation: "decorate", Type: typ, Name: name})
+	}
+
+	b, exist := injector.loadBindings()[typ][name]
+	if !exist {
+		return injector.errorMiddleWare(&MissingProviderError{Type: typ, Name: name})
+	}
+
+	// the singleton lock ensures the instance is either decorated here or when it is created
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// the decorator is only added once it succeeded on the existing instance, so a failing one leaves the binding usable
+	if b.instance != nil {
+		if injector.isVerbose() {
+			injector.logDebug(eventDecorate, fmt.Sprintf("existing %s for type `%s`", b.btype, fullyQualifiedTypeString(typ)), b.attrs()...)
+		}
+
+		decorated := decorator(b.instance)
+		if isNil(decorated) {
+			return injector.errorMiddleWare(&ProviderFailedError{Type: typ, Name: name, Provider: reflect.TypeOf(b.provider), Site: b.site, Err: errors.New("decorator returned a nil value")})
+		}
+		b.instance = decorated
+	}
+
+	b.decorators.mu.Lock()
+	b.decorators.list = append(b.decorators.list[:len(b.decorators.list):len(b.decorators.list)], decorator)
+	b.decorators.mu.Unlock()
+
+	return nil
+}
+
+// decorate passes a resolved instance through the decorators of its binding.
+func (injector *Injector) decorate(b *binding, instance interface{}, name string, state *resolution) (interface{}, error) {
+	for _, decorator := range b.decorators.get() {
+		if injector.isVerbose() {
+			injector.logDebug(eventDecorate, fmt.Sprintf("%s for type `%s`", b.btype, fullyQualifiedTypeString(reflect.TypeOf(b.provider).Out(0))), b.attrs()...)
+		}
+
+		instance = decorator(instance)
+		if isNil(instance) {
+			providerType := reflect.TypeOf(b.provider)
+			return nil, injector.errorMiddleWare(&ProviderFailedError{Type: providerType.Out(0), Name: name, Provider: providerType, Site: b.site, Path: state.chain(), Err: errors.New("decorator returned a nil value")})
+		}
+	}
+	return instance, nil
+}
+
+// isNil reports whether a value is nil or holds a nil pointer or interface.
+func isNil(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return value == nil || (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil()
+}
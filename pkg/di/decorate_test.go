@@ -0,0 +1,184 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type LoudGreeter struct {
+	Greeter Greeter
+}
+
+func (g *LoudGreeter) Greet() string {
+	return g.Greeter.Greet() + "!"
+}
+
+type PoliteGreeter struct {
+	Greeter Greeter
+}
+
+func (g *PoliteGreeter) Greet() string {
+	return "well, " + g.Greeter.Greet()
+}
+
+func TestInjector_Decorate(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() Greeter {
+		return &EnglishGreeter{}
+	})
+	injector.Singleton(func() *Welcome {
+		return &Welcome{}
+	})
+
+	calls := 0
+	assert.NoError(t, Decorate(injector, func(g Greeter) Greeter {
+		calls++
+		return &LoudGreeter{Greeter: g}
+	}))
+	assert.NoError(t, Decorate(injector, func(g Greeter) Greeter {
+		return &PoliteGreeter{Greeter: g}
+	}))
+
+	// decorators are applied in order, once for a singleton, and the decorated instance is injected
+	assert.Equal(t, "well, hello!", Get[Greeter](injector).Greet())
+	assert.Same(t, Get[Greeter](injector), Get[*Welcome](injector).Greeter)
+	assert.Equal(t, 1, calls)
+
+	// a singleton already created is decorated immediately
+	assert.NoError(t, Decorate(injector, func(g Greeter) Greeter {
+		return &LoudGreeter{Greeter: g}
+	}))
+	assert.Equal(t, "well, hello!!", Get[Greeter](injector).Greet())
+	assert.Equal(t, 1, calls)
+}
+
+func TestInjector_Decorate_Instance_And_Scoped(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.NamedInstance("english", func() Greeter {
+		return &EnglishGreeter{}
+	})
+	injector.Scoped(func() Greeter {
+		return &FrenchGreeter{}
+	})
+
+	calls := 0
+	assert.NoError(t, DecorateNamed(injector, "english", func(g Greeter) Greeter {
+		calls++
+		return &LoudGreeter{Greeter: g}
+	}))
+	assert.NoError(t, Decorate(injector, func(g Greeter) Greeter {
+		calls++
+		return &LoudGreeter{Greeter: g}
+	}))
+
+	// instance bindings are decorated on every resolution
+	assert.Equal(t, "hello!", NamedGet[Greeter](injector, "english").Greet())
+	assert.Equal(t, "hello!", NamedGet[Greeter](injector, "english").Greet())
+	assert.Equal(t, 2, calls)
+
+	// scoped instances are decorated once per scope
+	first, second := injector.NewScope(), injector.NewScope()
+	assert.Equal(t, "bonjour!", Get[Greeter](first).Greet())
+	assert.Same(t, Get[Greeter](first), Get[Greeter](first))
+	assert.Equal(t, "bonjour!", Get[Greeter](second).Greet())
+	assert.Equal(t, 4, calls)
+}
+
+func TestInjector_Decorate_Replace(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() Greeter {
+		return &EnglishGreeter{}
+	})
+	assert.NoError(t, Decorate(injector, func(g Greeter) Greeter {
+		return &LoudGreeter{Greeter: g}
+	}))
+
+	// decorators apply to the replacing provider and to overrides
+	assert.NoError(t, Replace[Greeter](injector, func() Greeter {
+		return &FrenchGreeter{}
+	}))
+	assert.Equal(t, "bonjour!", Get[Greeter](injector).Greet())
+
+	restore, err := Override[Greeter](injector, func() Greeter {
+		return &EnglishGreeter{}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello!", Get[Greeter](injector).Greet())
+	restore()
+
+	// decorators added after a snapshot are rolled back with it
+	snap := injector.Snapshot()
+	assert.NoError(t, Decorate(injector, func(g Greeter) Greeter {
+		return &PoliteGreeter{Greeter: g}
+	}))
+	assert.Equal(t, "well, bonjour!", Get[Greeter](injector).Greet())
+	assert.NoError(t, injector.Restore(snap))
+	assert.Equal(t, "bonjour!", Get[Greeter](injector).Greet())
+}
+
+func TestInjector_Decorate_Fail(t *testing.T) {
+	var errs []error
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})
+
+	err := Decorate(injector, func(g Greeter) Greeter {
+		return g
+	})
+	assert.ErrorIs(t, err, ErrMissingProvider)
+
+	injector.Instance(func() Greeter {
+		return &EnglishGreeter{}
+	})
+	err = Decorate[Greeter](injector, nil)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	assert.NoError(t, Decorate(injector, func(g Greeter) Greeter {
+		return nil
+	}))
+	errs = nil
+	Get[Greeter](injector)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrProviderFailed)
+	assert.ErrorContains(t, errs[0], "decorator returned a nil value")
+
+	injector.Freeze()
+	err = Decorate(injector, func(g Greeter) Greeter {
+		return g
+	})
+	assert.ErrorIs(t, err, ErrFrozen)
+}
+
+func TestInjector_Decorate_Fail_Existing(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() Greeter {
+		return &EnglishGreeter{}
+	})
+	greeter := Get[Greeter](injector)
+
+	// a decorator failing on the existing instance is not added
+	err := Decorate(injector, func(g Greeter) Greeter {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrProviderFailed)
+	assert.Same(t, greeter, Get[Greeter](injector))
+
+	assert.NoError(t, Replace[Greeter](injector, func() Greeter {
+		return &EnglishGreeter{}
+	}))
+	assert.Equal(t, "hello", Get[Greeter](injector).Greet())
+}
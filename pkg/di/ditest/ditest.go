@@ -0,0 +1,43 @@
+// Package ditest provides helpers for tests of code wired with the di package.
+package ditest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thinkdata-works/godi/pkg/di"
+)
+
+// Override replaces the unnamed binding of the type for the duration of the test.
+// See OverrideNamed.
+func Override[Type any](t testing.TB, i *di.Injector, provider interface{}, options ...di.BindingOption) {
+	t.Helper()
+	override(t, reflect.TypeFor[Type](), "", func() (func(), error) {
+		return di.Override[Type](i, provider, options...)
+	})
+}
+
+// OverrideNamed replaces the binding of the type registered under the name for the duration of the test.
+// Singletons depending on the type are created again with the override, and the original bindings
+// are restored when the test and its subtests complete. The test fails immediately if the binding
+// cannot be overridden.
+//
+// Overrides apply to every user of the injector, including the di.GlobalInjector. Parallel tests
+// can override different types of a shared injector, but should not rely on the same type.
+func OverrideNamed[Type any](t testing.TB, i *di.Injector, name string, provider interface{}, options ...di.BindingOption) {
+	t.Helper()
+	override(t, reflect.TypeFor[Type](), name, func() (func(), error) {
+		return di.OverrideNamed[Type](i, name, provider, options...)
+	})
+}
+
+// override applies an override and registers its restoration as a cleanup of the test.
+func override(t testing.TB, typ reflect.Type, name string, apply func() (func(), error)) {
+	t.Helper()
+
+	restore, err := apply()
+	if err != nil {
+		t.Fatalf("ditest: cannot override the binding of `%s` named %q: %s", typ, name, err)
+	}
+	t.Cleanup(restore)
+}
@@ -0,0 +1,71 @@
+package ditest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thinkdata-works/godi/pkg/di"
+)
+
+type Clock interface {
+	Now() int
+}
+
+type clock struct {
+	now int
+}
+
+func (c *clock) Now() int {
+	return c.now
+}
+
+type Scheduler struct {
+	Clock Clock `di:"type"`
+}
+
+func TestOverride(t *testing.T) {
+	var injector = di.NewInjector()
+	injector.Singleton(func() Clock {
+		return &clock{now: 1}
+	})
+	injector.Singleton(func() *Scheduler {
+		return &Scheduler{}
+	})
+	injector.NamedSingleton("utc", func() Clock {
+		return &clock{now: 2}
+	})
+	assert.Equal(t, 1, di.Get[*Scheduler](injector).Clock.Now())
+
+	t.Run("override", func(t *testing.T) {
+		Override[Clock](t, injector, func() Clock {
+			return &clock{now: 10}
+		})
+		OverrideNamed[Clock](t, injector, "utc", func() Clock {
+			return &clock{now: 20}
+		})
+
+		assert.Equal(t, 10, di.Get[*Scheduler](injector).Clock.Now())
+		assert.Equal(t, 20, di.NamedGet[Clock](injector, "utc").Now())
+	})
+
+	assert.Equal(t, 1, di.Get[*Scheduler](injector).Clock.Now())
+	assert.Equal(t, 2, di.NamedGet[Clock](injector, "utc").Now())
+}
+
+func TestOverride_GlobalInjector(t *testing.T) {
+	di.Singleton(func() Clock {
+		return &clock{now: 1}
+	})
+	t.Cleanup(func() {
+		di.Unbind[Clock](di.GlobalInjector)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		Override[Clock](t, di.GlobalInjector, func() Clock {
+			return &clock{now: 10}
+		})
+		assert.Equal(t, 10, di.Get[Clock](di.GlobalInjector).Now())
+	})
+
+	assert.Equal(t, 1, di.Get[Clock](di.GlobalInjector).Now())
+}
@@ -0,0 +1,198 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Sentinel errors matched by the typed errors below, for use with errors.Is.
+var (
+	ErrMissingProvider    = errors.New("di: missing provider")
+	ErrProviderFailed     = errors.New("di: provider failed")
+	ErrInvalidTag         = errors.New("di: invalid struct tag")
+	ErrInvalidProvider    = errors.New("di: invalid provider")
+	ErrInvalidField       = errors.New("di: invalid field")
+	ErrInvalidArgument    = errors.New("di: invalid argument")
+	ErrCircularDependency = errors.New("di: circular dependency")
+	ErrCanceled           = errors.New("di: resolution canceled")
+)
+
+// MissingProviderError is returned when no binding exists for a requested type and name.
+type MissingProviderError struct {
+	Type  reflect.Type   // the requested type
+	Name  string         // the requested binding name, empty for unnamed bindings
+	Field string         // the struct field being filled, if any
+	Site  string         // file:line the binding requiring the type was registered from, if any
+	Path  []reflect.Type // the chain of types being resolved when the failure happened
+}
+
+func (e *MissingProviderError) Error() string {
+	msg := fmt.Sprintf("no provider found for type `%s`", fullyQualifiedTypeString(e.Type))
+	if e.Name != "" {
+		msg += fmt.Sprintf(" under name `%s`", e.Name)
+	}
+	if e.Field != "" {
+		msg = fmt.Sprintf("cannot resolve field `%s`, %s", e.Field, msg)
+	}
+	if e.Site != "" {
+		msg += fmt.Sprintf(", required by the binding registered at %s", e.Site)
+	}
+	return msg + ", ensure the type provided matches the return value of the provider" + pathString(e.Path, e.Type)
+}
+
+func (e *MissingProviderError) Is(target error) bool {
+	return target == ErrMissingProvider
+}
+
+// ProviderFailedError is returned when a provider returns an error or a nil value.
+type ProviderFailedError struct {
+	Type     reflect.Type   // the type the provider is bound to
+	Name     string         // the binding name, empty for unnamed bindings
+	Provider reflect.Type   // the type of the provider function
+	Site     string         // file:line the binding was registered from, empty for Call
+	Path     []reflect.Type // the chain of types being resolved when the failure happened
+	Err      error          // the error returned by the provider
+}
+
+func (e *ProviderFailedError) Error() string {
+	return fmt.Sprintf("provider `%s` for type `%s`%s failed: %s%s", fullyQualifiedTypeString(e.Provider), fullyQualifiedTypeString(e.Type), registeredAt(e.Site), e.Err, pathString(e.Path, nil))
+}
+
+func (e *ProviderFailedError) Is(target error) bool {
+	return target == ErrProviderFailed
+}
+
+func (e *ProviderFailedError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidTagError is returned when a struct field has a `di` tag that cannot be parsed.
+type InvalidTagError struct {
+	Type  reflect.Type   // the struct type holding the field
+	Field string         // the name of the field
+	Tag   string         // the value of the tag
+	Path  []reflect.Type // the chain of types being resolved when the failure happened
+}
+
+func (e *InvalidTagError) Error() string {
+	return fmt.Sprintf("field `%s` of `%s` has an invalid struct tag `%s`%s", e.Field, fullyQualifiedTypeString(e.Type), e.Tag, pathString(e.Path, nil))
+}
+
+func (e *InvalidTagError) Is(target error) bool {
+	return target == ErrInvalidTag
+}
+
+// InvalidFieldError is returned when a tagged struct field cannot hold an injected value.
+type InvalidFieldError struct {
+	Type   reflect.Type   // the struct type holding the field
+	Field  string         // the name of the field
+	Reason string         // why the field cannot be injected
+	Path   []reflect.Type // the chain of types being resolved when the failure happened
+}
+
+func (e *InvalidFieldError) Error() string {
+	return fmt.Sprintf("field `%s` of `%s` %s%s", e.Field, fullyQualifiedTypeString(e.Type), e.Reason, pathString(e.Path, nil))
+}
+
+func (e *InvalidFieldError) Is(target error) bool {
+	return target == ErrInvalidField
+}
+
+// InvalidProviderError is returned when a provider is registered with an unsupported signature.
+type InvalidProviderError struct {
+	Provider reflect.Type // the type of the provider, nil if the provider was nil
+	Name     string       // the binding name, empty for unnamed bindings
+	Reason   string       // why the provider was rejected
+}
+
+func (e *InvalidProviderError) Error() string {
+	if e.Provider == nil || e.Provider.Kind() != reflect.Func {
+		return fmt.Sprintf("provider argument must be a function, %s", e.Reason)
+	}
+	return fmt.Sprintf("provider function signature of `%s` is invalid, %s", fullyQualifiedTypeString(e.Provider), e.Reason)
+}
+
+func (e *InvalidProviderError) Is(target error) bool {
+	return target == ErrInvalidProvider
+}
+
+// InvalidArgumentError is returned when an argument passed to the injector cannot be resolved into.
+type InvalidArgumentError struct {
+	Type   reflect.Type // the type of the argument, nil if the argument was nil
+	Reason string       // why the argument was rejected
+}
+
+func (e *InvalidArgumentError) Error() string {
+	if e.Type == nil {
+		return fmt.Sprintf("invalid nil argument, %s", e.Reason)
+	}
+	return fmt.Sprintf("invalid argument of type `%s`, %s", fullyQualifiedTypeString(e.Type), e.Reason)
+}
+
+func (e *InvalidArgumentError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// CircularDependencyError is returned when provider arguments depend on each other.
+type CircularDependencyError struct {
+	Type reflect.Type   // the type that was requested while its provider was still being invoked
+	Name string         // the binding name, empty for unnamed bindings
+	Site string         // file:line the binding was registered from
+	Path []reflect.Type // the chain of types being resolved when the cycle was found
+}
+
+func (e *CircularDependencyError) Error() string {
+	return fmt.Sprintf("circular dependency detected while resolving the arguments of the provider for `%s`%s%s", fullyQualifiedTypeString(e.Type), registeredAt(e.Site), pathString(e.Path, e.Type))
+}
+
+func (e *CircularDependencyError) Is(target error) bool {
+	return target == ErrCircularDependency
+}
+
+// CanceledError is returned when the context of a resolution is done before it completes.
+// It wraps the error of the context, so it matches context.Canceled or context.DeadlineExceeded.
+type CanceledError struct {
+	Type reflect.Type   // the type being resolved when the resolution was abandoned
+	Name string         // the binding name, empty for unnamed bindings
+	Site string         // file:line the binding was registered from
+	Path []reflect.Type // the chain of types being resolved, ending with the type
+	Err  error          // the error of the context
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("resolution of type `%s`%s abandoned: %s%s", fullyQualifiedTypeString(e.Type), registeredAt(e.Site), e.Err, pathString(e.Path, nil))
+}
+
+func (e *CanceledError) Is(target error) bool {
+	return target == ErrCanceled
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// registeredAt formats a registration site for messages, empty if the site is unknown.
+func registeredAt(site string) string {
+	if site == "" {
+		return ""
+	}
+	return fmt.Sprintf(" registered at %s", site)
+}
+
+// pathString formats a resolution chain as ` (resolving A -> B -> C)`, appending the requested type if present.
+func pathString(path []reflect.Type, requested reflect.Type) string {
+	if requested != nil {
+		path = append(path[:len(path):len(path)], requested)
+	}
+	if len(path) < 2 {
+		return ""
+	}
+
+	types := make([]string, len(path))
+	for i, t := range path {
+		types[i] = fullyQualifiedTypeString(t)
+	}
+	return fmt.Sprintf(" (resolving %s)", strings.Join(types, " -> "))
+}
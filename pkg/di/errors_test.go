@@ -0,0 +1,139 @@
+package di
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjector_Errors_Missing_Provider(t *testing.T) {
+	var injector = NewInjector()
+	injector.Singleton(func() *A {
+		return &A{}
+	})
+	injector.Singleton(func() *B {
+		return &B{}
+	})
+
+	_, err := TryGet[*A](injector)
+	assert.ErrorIs(t, err, ErrMissingProvider)
+
+	var missing *MissingProviderError
+	if assert.ErrorAs(t, err, &missing) {
+		assert.Equal(t, reflect.TypeFor[*C](), missing.Type)
+		assert.Equal(t, "", missing.Name)
+		assert.Equal(t, "C", missing.Field)
+		assert.Equal(t, []reflect.Type{reflect.TypeFor[*A](), reflect.TypeFor[*B]()}, missing.Path)
+		assert.Contains(t, err.Error(), "(resolving *di.A -> *di.B -> *di.C)")
+
+		// the site of the binding of *B, which requires *C
+		assert.Contains(t, missing.Site, "errors_test.go:")
+		assert.Contains(t, err.Error(), "required by the binding registered at "+missing.Site)
+	}
+
+	_, err = TryNamedGet[Shape](injector, "circle")
+	if assert.ErrorAs(t, err, &missing) {
+		assert.Equal(t, reflect.TypeFor[Shape](), missing.Type)
+		assert.Equal(t, "circle", missing.Name)
+		assert.Empty(t, missing.Path)
+		assert.Empty(t, missing.Site)
+	}
+}
+
+func TestInjector_Errors_Provider_Failed(t *testing.T) {
+	var injector = NewInjector()
+	providerErr := errors.New("connection refused")
+	injector.Singleton(func() *A {
+		return &A{}
+	})
+	injector.Singleton(func() *B {
+		return &B{}
+	})
+	injector.NamedSingleton("db", func() (*C, error) {
+		return nil, providerErr
+	})
+	injector.Singleton(func() (*C, error) {
+		return nil, providerErr
+	})
+
+	_, err := TryGet[*A](injector)
+	assert.ErrorIs(t, err, ErrProviderFailed)
+	assert.ErrorIs(t, err, providerErr)
+
+	var failed *ProviderFailedError
+	if assert.ErrorAs(t, err, &failed) {
+		assert.Equal(t, reflect.TypeFor[*C](), failed.Type)
+		assert.Equal(t, reflect.TypeFor[func() (*C, error)](), failed.Provider)
+		assert.Equal(t, []reflect.Type{reflect.TypeFor[*A](), reflect.TypeFor[*B](), reflect.TypeFor[*C]()}, failed.Path)
+		assert.Equal(t, providerErr, failed.Err)
+		assert.Contains(t, failed.Site, "errors_test.go:")
+		assert.Contains(t, err.Error(), "registered at "+failed.Site)
+	}
+
+	_, err = TryNamedGet[*C](injector, "db")
+	if assert.ErrorAs(t, err, &failed) {
+		assert.Equal(t, "db", failed.Name)
+	}
+
+	injector.Instance(func() *John {
+		return nil
+	})
+	_, err = TryGet[*John](injector)
+	assert.ErrorIs(t, err, ErrProviderFailed)
+}
+
+func TestInjector_Errors_Invalid(t *testing.T) {
+	var injector = NewInjector()
+	var errs []error
+	injector.SetErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})
+
+	injector.Singleton("STRING!")
+	injector.Singleton(func() TypeC { return TypeC{} })
+	if assert.Len(t, errs, 2) {
+		var invalid *InvalidProviderError
+		assert.ErrorAs(t, errs[0], &invalid)
+		assert.ErrorIs(t, errs[1], ErrInvalidProvider)
+	}
+
+	errs = nil
+	injector.Fill(&struct {
+		S Shape `di:"invalid"`
+	}{})
+	if assert.Len(t, errs, 1) {
+		var invalid *InvalidTagError
+		if assert.ErrorAs(t, errs[0], &invalid) {
+			assert.Equal(t, "S", invalid.Field)
+			assert.Equal(t, "invalid", invalid.Tag)
+		}
+	}
+
+	errs = nil
+	var s Shape
+	injector.Resolve(s)
+	injector.Call("STRING!")
+	if assert.Len(t, errs, 2) {
+		assert.ErrorIs(t, errs[0], ErrInvalidArgument)
+		assert.ErrorIs(t, errs[1], ErrInvalidArgument)
+	}
+
+	errs = nil
+	injector.Singleton(func(b *Bob) *Alice {
+		return &Alice{Bob: b}
+	})
+	injector.Singleton(func(a *Alice) *Bob {
+		return &Bob{Alice: a}
+	})
+	injector.Call(func(a *Alice) {})
+	if assert.Len(t, errs, 1) {
+		var circular *CircularDependencyError
+		if assert.ErrorAs(t, errs[0], &circular) {
+			assert.Equal(t, reflect.TypeFor[*Alice](), circular.Type)
+			assert.Equal(t, []reflect.Type{reflect.TypeFor[*Alice](), reflect.TypeFor[*Bob]()}, circular.Path)
+			assert.Contains(t, circular.Site, "errors_test.go")
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// OverwritePolicy decides what happens when a registration replaces an existing binding of the same type and name.
+type OverwritePolicy int32
+
+const (
+	// OverwriteAllow silently replaces the existing binding, this is the default.
+	OverwriteAllow OverwritePolicy = iota
+	// OverwriteWarn replaces the existing binding and logs a warning with both registration sites.
+	OverwriteWarn
+	// OverwriteFail keeps the existing binding and fails the registration with a *DuplicateBindingError.
+	OverwriteFail
+)
+
+// Sentinel errors matched by the errors below, for use with errors.Is.
+var (
+	ErrFrozen           = errors.New("di: injector is frozen")
+	ErrDuplicateBinding = errors.New("di: duplicate binding")
+)
+
+// FrozenError is returned when a frozen injector is modified.
+type FrozenError struct {
+	Operation string       // the method that attempted the modification
+	Type      reflect.Type // the type of the binding being modified, nil when not specific to a binding
+	Name      string       // the binding name, empty for unnamed bindings
+}
+
+func (e *FrozenError) Error() string {
+	if e.Type == nil {
+		return fmt.Sprintf("injector is frozen, cannot %s", e.Operation)
+	}
+	return fmt.Sprintf("injector is frozen, cannot %s the binding for type `%s`", e.Operation, fullyQualifiedTypeString(e.Type))
+}
+
+func (e *FrozenError) Is(target error) bool {
+	return target == ErrFrozen
+}
+
+// DuplicateBindingError is returned when a registration would replace an existing binding under OverwriteFail.
+type DuplicateBindingError struct {
+	Type         reflect.Type // the type of the binding
+	Name         string       // the binding name, empty for unnamed bindings
+	Site         string       // file:line of the rejected registration
+	ExistingSite string       // file:line of the existing registration
+}
+
+func (e *DuplicateBindingError) Error() string {
+	return fmt.Sprintf("a binding for type `%s`%s registered at %s already exists, cannot replace it with the registration at %s", fullyQualifiedTypeString(e.Type), nameString(e.Name), e.ExistingSite, e.Site)
+}
+
+func (e *DuplicateBindingError) Is(target error) bool {
+	return target == ErrDuplicateBinding
+}
+
+// nameString formats a binding name for messages, empty for unnamed bindings.
+func nameString(name string) string {
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf(" under name `%s`", name)
+}
+
+// Freeze prevents any further changes to the bindings of the injector.
+// Once frozen, registrations and Reset fail with a *FrozenError. Scopes created from a frozen injector
+// can still register their own bindings.
+func (injector *Injector) Freeze() {
+	if injector.isVerbose() {
+		injector.logCall("Freeze")
+	}
+
+	injector.bindMu.Lock()
+	defer injector.bindMu.Unlock()
+
+	atomic.StoreInt32(&injector.frozen, 1)
+}
+
+// Frozen reports whether the injector has been frozen.
+func (injector *Injector) Frozen() bool {
+	return atomic.LoadInt32(&injector.frozen) != 0
+}
+
+// SetOverwritePolicy sets what happens when a registration replaces an existing binding of the same type and name.
+// Bindings registered in a scope that shadow the bindings of a parent are not considered overwrites.
+func (injector *Injector) SetOverwritePolicy(policy OverwritePolicy) {
+	atomic.StoreInt32(&injector.overwritePolicy, int32(policy))
+}
+
+// checkOverwrite applies the overwrite policy to a registration, within writeBindings.
+func (injector *Injector) checkOverwrite(bindings map[reflect.Type]map[string]*binding, typ reflect.Type, b *binding) error {
+	existing, exist := bindings[typ][b.name]
+	if !exist {
+		return nil
+	}
+
+	switch OverwritePolicy(atomic.LoadInt32(&injector.overwritePolicy)) {
+	case OverwriteWarn:
+		injector.logWarning(eventBind, fmt.Sprintf("the binding for type `%s`%s registered at %s is replaced by the registration at %s", fullyQualifiedTypeString(typ), nameString(b.name), existing.site, b.site), b.attrs()...)
+	case OverwriteFail:
+		return &DuplicateBindingError{Type: typ, Name: b.name, Site: b.site, ExistingSite: existing.site}
+	}
+	return nil
+}
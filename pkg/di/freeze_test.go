@@ -0,0 +1,101 @@
+package di
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjector_Freeze(t *testing.T) {
+	var injector = NewInjector()
+	var errs []error
+	injector.SetErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})
+	injector.Singleton(func() Database {
+		return &MySQL{}
+	})
+	injector.Freeze()
+	assert.True(t, injector.Frozen())
+
+	injector.NamedSingleton("other", func() Database {
+		return &MySQL{}
+	})
+	Bind[Notifier, *EmailNotifier](injector)
+	injector.Reset()
+
+	assert.Len(t, errs, 3)
+	for _, err := range errs {
+		assert.ErrorIs(t, err, ErrFrozen)
+	}
+
+	var frozenErr *FrozenError
+	assert.True(t, errors.As(errs[0], &frozenErr))
+	assert.Equal(t, "register", frozenErr.Operation)
+	assert.Equal(t, "other", frozenErr.Name)
+	assert.True(t, errors.As(errs[2], &frozenErr))
+	assert.Equal(t, "reset", frozenErr.Operation)
+
+	// the existing bindings are untouched and can still be resolved
+	assert.NotNil(t, Get[Database](injector))
+	_, err := TryNamedGet[Database](injector, "other")
+	assert.ErrorIs(t, err, ErrMissingProvider)
+
+	// scopes of a frozen injector can still register their own bindings
+	errs = nil
+	scope := injector.NewScope()
+	assert.False(t, scope.Frozen())
+	scope.NamedScoped("tx", func() Database {
+		return &MySQL{}
+	})
+	assert.Empty(t, errs)
+	assert.NotNil(t, NamedGet[Database](scope, "tx"))
+}
+
+func TestInjector_OverwritePolicy(t *testing.T) {
+	var injector = NewInjector()
+	var errs []error
+	injector.SetErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})
+
+	// replacing bindings is allowed by default
+	injector.Singleton(func() Database { return &MySQL{} })
+	injector.Singleton(func() Database { return &MySQL{} })
+	assert.Empty(t, errs)
+
+	injector.SetOverwritePolicy(OverwriteFail)
+	injector.Singleton(func() Database { return &MySQL{} })
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], ErrDuplicateBinding)
+
+		var duplicate *DuplicateBindingError
+		assert.True(t, errors.As(errs[0], &duplicate))
+		assert.Contains(t, duplicate.Site, "freeze_test.go")
+		assert.Contains(t, duplicate.ExistingSite, "freeze_test.go")
+		assert.NotEqual(t, duplicate.Site, duplicate.ExistingSite)
+	}
+
+	// named bindings and the error results of two-value providers are not duplicates
+	errs = nil
+	injector.NamedSingleton("replica", func() Database { return &MySQL{} })
+	injector.Instance(func() (*John, error) { return &John{}, nil })
+	injector.Instance(func() (*C, error) { return &C{}, nil })
+
+	// shadowing a parent binding in a scope is not an overwrite
+	scope := injector.NewScope()
+	scope.SetOverwritePolicy(OverwriteFail)
+	scope.Scoped(func() Database { return &MySQL{} })
+	assert.Empty(t, errs)
+
+	var buf bytes.Buffer
+	injector.SetSlogLogger(slog.New(NewPrettyHandler(&buf, nil)))
+	injector.SetOverwritePolicy(OverwriteWarn)
+	injector.Singleton(func() Database { return &MySQL{} })
+	assert.Empty(t, errs)
+	assert.Contains(t, buf.String(), "WARNING")
+	assert.Contains(t, buf.String(), "freeze_test.go")
+}
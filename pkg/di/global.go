@@ -1,9 +1,11 @@
 package di
 
 import (
-	"fmt"
+	"context"
 	"log"
+	"log/slog"
 	"reflect"
+	"time"
 )
 
 // GlobalInjector is the global repository of bindings
@@ -19,6 +21,11 @@ func SetLogger(logger log.Logger) {
 	GlobalInjector.SetLogger(logger)
 }
 
+// SetSlogLogger sends the records of the global injector to a structured logger.
+func SetSlogLogger(logger *slog.Logger) {
+	GlobalInjector.SetSlogLogger(logger)
+}
+
 // EnableDebugLogging enables debug logging.
 func EnableDebugLogging() {
 	GlobalInjector.EnableDebugLogging()
@@ -29,28 +36,98 @@ func DisableDebugLogging() {
 	GlobalInjector.DisableDebugLogging()
 }
 
+// SetTimeout bounds every resolution call made through the global injector.
+func SetTimeout(timeout time.Duration) {
+	GlobalInjector.SetTimeout(timeout)
+}
+
+// SetSlowProviderThreshold logs a warning for every provider of the global injector slower than the threshold.
+func SetSlowProviderThreshold(threshold time.Duration) {
+	GlobalInjector.SetSlowProviderThreshold(threshold)
+}
+
+// AddObserver registers an observer receiving the events of the global injector.
+func AddObserver(observer Observer) (remove func()) {
+	return GlobalInjector.AddObserver(observer)
+}
+
+// SetMetricsSink sets a sink receiving the measurements of the global injector.
+func SetMetricsSink(sink MetricsSink) {
+	GlobalInjector.SetMetricsSink(sink)
+}
+
+// Stats returns the measurements of every binding resolved through the global injector.
+func Stats() []BindingStats {
+	return GlobalInjector.Stats()
+}
+
 // Singleton binds an abstraction to concrete for further singleton resolves.
 // It takes a resolver function that returns the concrete, and its return type matches the abstraction (interface).
 // The resolver function can have arguments of abstraction that have been declared in the Injector already.
-func Singleton(resolver interface{}) {
-	GlobalInjector.Singleton(resolver)
+func Singleton(resolver interface{}, options ...BindingOption) {
+	GlobalInjector.Singleton(resolver, options...)
 }
 
 // NamedSingleton binds like the Singleton method but for named bindings.
-func NamedSingleton(name string, resolver interface{}) {
-	GlobalInjector.NamedSingleton(name, resolver)
+func NamedSingleton(name string, resolver interface{}, options ...BindingOption) {
+	GlobalInjector.NamedSingleton(name, resolver, options...)
 }
 
 // Instance binds an abstraction to concrete for further transient resolves.
 // It takes a resolver function that returns the concrete, and its return type matches the abstraction (interface).
 // The resolver function can have arguments of abstraction that have been declared in the Injector already.
-func Instance(resolver interface{}) {
-	GlobalInjector.Instance(resolver)
+func Instance(resolver interface{}, options ...BindingOption) {
+	GlobalInjector.Instance(resolver, options...)
 }
 
 // NamedInstance binds like the Instance method but for named bindings.
-func NamedInstance(name string, resolver interface{}) {
-	GlobalInjector.NamedInstance(name, resolver)
+func NamedInstance(name string, resolver interface{}, options ...BindingOption) {
+	GlobalInjector.NamedInstance(name, resolver, options...)
+}
+
+// Start eagerly creates and starts the singletons of the global injector.
+func Start(ctx context.Context) error {
+	return GlobalInjector.Start(ctx)
+}
+
+// Close releases every singleton created by the global injector, in reverse creation order.
+func Close(ctx context.Context) error {
+	return GlobalInjector.Close(ctx)
+}
+
+// Scoped binds an abstraction to concrete for resolves shared within a scope.
+func Scoped(resolver interface{}, options ...BindingOption) {
+	GlobalInjector.Scoped(resolver, options...)
+}
+
+// NamedScoped binds like the Scoped method but for named bindings.
+func NamedScoped(name string, resolver interface{}, options ...BindingOption) {
+	GlobalInjector.NamedScoped(name, resolver, options...)
+}
+
+// NewScope creates a child injector of the global injector.
+func NewScope() *Injector {
+	return GlobalInjector.NewScope()
+}
+
+// Freeze prevents any further changes to the bindings of the global injector.
+func Freeze() {
+	GlobalInjector.Freeze()
+}
+
+// SetOverwritePolicy sets what happens when a registration replaces an existing binding of the global injector.
+func SetOverwritePolicy(policy OverwritePolicy) {
+	GlobalInjector.SetOverwritePolicy(policy)
+}
+
+// Snapshot captures the state of the global injector.
+func Snapshot() *InjectorSnapshot {
+	return GlobalInjector.Snapshot()
+}
+
+// Restore rolls the global injector back to a snapshot.
+func Restore(snap *InjectorSnapshot) error {
+	return GlobalInjector.Restore(snap)
 }
 
 // Reset deletes all the existing bindings and empties the container instance.
@@ -58,6 +135,26 @@ func Reset() {
 	GlobalInjector.Reset()
 }
 
+// Validate checks every registered binding of the global injector without invoking any provider.
+func Validate() error {
+	return GlobalInjector.Validate()
+}
+
+// Graph returns the dependency graph of the global injector.
+func Graph() *DependencyGraph {
+	return GlobalInjector.Graph()
+}
+
+// Bindings describes every binding of the global injector.
+func Bindings() []BindingInfo {
+	return GlobalInjector.Bindings()
+}
+
+// Describe describes the binding of a type and name of the global injector.
+func Describe(typ reflect.Type, name string) (BindingInfo, bool) {
+	return GlobalInjector.Describe(typ, name)
+}
+
 // Call takes a function (receiver) with one or more arguments of the abstractions (interfaces).
 // It invokes the function (receiver) and passes the related implementations.
 func Call(receiver interface{}) {
@@ -79,32 +176,79 @@ func Fill(receiver interface{}) {
 	GlobalInjector.Fill(receiver)
 }
 
+// TryCall calls like the Call function but returns any error instead of passing it to the error handler.
+func TryCall(receiver interface{}) error {
+	return GlobalInjector.TryCall(receiver)
+}
+
+// TryResolve resolves like the Resolve function but returns any error instead of passing it to the error handler.
+func TryResolve(abstraction interface{}) error {
+	return GlobalInjector.TryResolve(abstraction)
+}
+
+// TryNamedResolve resolves like the NamedResolve function but returns any error instead of passing it to the error handler.
+func TryNamedResolve(abstraction interface{}, name string) error {
+	return GlobalInjector.TryNamedResolve(abstraction, name)
+}
+
+// TryFill fills like the Fill function but returns any error instead of passing it to the error handler.
+func TryFill(receiver interface{}) error {
+	return GlobalInjector.TryFill(receiver)
+}
+
+// ResolveContext resolves like the TryResolve function, abandoning the resolution once the context is done.
+func ResolveContext(ctx context.Context, abstraction interface{}) error {
+	return GlobalInjector.ResolveContext(ctx, abstraction)
+}
+
+// NamedResolveContext resolves like the ResolveContext function but for named bindings.
+func NamedResolveContext(ctx context.Context, abstraction interface{}, name string) error {
+	return GlobalInjector.NamedResolveContext(ctx, abstraction, name)
+}
+
+// FillContext fills like the TryFill function, abandoning the resolution once the context is done.
+func FillContext(ctx context.Context, receiver interface{}) error {
+	return GlobalInjector.FillContext(ctx, receiver)
+}
+
+// CallContext calls like the TryCall function, abandoning the resolution once the context is done.
+func CallContext(ctx context.Context, receiver interface{}) error {
+	return GlobalInjector.CallContext(ctx, receiver)
+}
+
 // Get takes a pointer or interface type argument and returns the provided implemenation.
 func Get[Type any](i *Injector) Type {
-	defer func() {
-		if r := recover(); r != nil {
-			i.handleError(fmt.Errorf("unable to resolve %s, returning empty value", reflect.TypeFor[Type]().String()))
-		}
-	}()
-
-	if reflect.TypeFor[Type]().Kind() != reflect.Interface && reflect.TypeFor[Type]().Kind() != reflect.Ptr {
-		i.handleError(fmt.Errorf("unable to resolve %s, type must be either a pointer or an interface, not: %s", reflect.TypeFor[Type]().String(), reflect.TypeFor[Type]().Kind()))
+	instance, err := TryGet[Type](i)
+	if err != nil {
+		i.handleError(err)
 	}
-
-	return i.get(reflect.TypeFor[Type](), "").(Type)
+	return instance
 }
 
 // NamedGet takes a pointer or interface type argument and a name string and returns the provided implemenation.
 func NamedGet[Type any](i *Injector, name string) Type {
-	defer func() {
-		if r := recover(); r != nil {
-			i.handleError(fmt.Errorf("unable to resolve %s, returning empty value", reflect.TypeFor[Type]().String()))
-		}
-	}()
-
-	if reflect.TypeFor[Type]().Kind() != reflect.Interface && reflect.TypeFor[Type]().Kind() != reflect.Ptr {
-		i.handleError(fmt.Errorf("unable to resolve %s, type must be either a pointer or an interface, not: %s", reflect.TypeFor[Type]().String(), reflect.TypeFor[Type]().Kind()))
+	instance, err := TryNamedGet[Type](i, name)
+	if err != nil {
+		i.handleError(err)
+	}
+	return instance
+}
+
+// TryGet gets like the Get function but returns any error instead of passing it to the error handler.
+// The empty value of the type is returned alongside the error.
+func TryGet[Type any](i *Injector) (Type, error) {
+	return TryNamedGet[Type](i, "")
+}
+
+// TryNamedGet gets like the NamedGet function but returns any error instead of passing it to the error handler.
+// The empty value of the type is returned alongside the error.
+func TryNamedGet[Type any](i *Injector, name string) (Type, error) {
+	var empty Type
+
+	instance, err := i.get(context.Background(), reflect.TypeFor[Type](), name)
+	if err != nil {
+		return empty, err
 	}
 
-	return i.get(reflect.TypeFor[Type](), name).(Type)
+	return instance.(Type), nil
 }
@@ -0,0 +1,268 @@
+package di
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DependencyKind tells how a binding depends on another.
+type DependencyKind string
+
+const (
+	// DependencyParam is a dependency declared as a provider parameter.
+	DependencyParam DependencyKind = "param"
+	// DependencyField is a dependency declared as a tagged struct field filled after the provider returns.
+	DependencyField DependencyKind = "field"
+)
+
+// DependencyGraph is the dependency graph of an injector, derived from provider signatures and `di` struct tags
+// without invoking any provider.
+type DependencyGraph struct {
+	Nodes []GraphNode `json:"nodes"` // the bindings, ordered by type and name, followed by missing dependencies
+	Edges []GraphEdge `json:"edges"` // the dependencies between nodes
+}
+
+// GraphNode is a binding, or a dependency without a binding.
+type GraphNode struct {
+	ID       string       `json:"id"`                 // unique identifier of the node, made of the type and name
+	Type     reflect.Type `json:"-"`                  // the type the binding is registered under
+	TypeName string       `json:"type"`               // the fully qualified name of Type
+	Name     string       `json:"name,omitempty"`     // the binding name, empty for unnamed bindings
+	Lifetime string       `json:"lifetime,omitempty"` // singleton, scoped or instance, empty for missing dependencies
+	Site     string       `json:"site,omitempty"`     // file:line the binding was registered from
+	Missing  bool         `json:"missing,omitempty"`  // whether the node is a dependency that has no binding
+}
+
+// GraphEdge is a dependency of one node on another.
+type GraphEdge struct {
+	From     string         `json:"from"`               // ID of the dependent node
+	To       string         `json:"to"`                 // ID of the node depended on
+	Kind     DependencyKind `json:"kind"`               // whether the dependency is a provider parameter or a struct field
+	Label    string         `json:"label"`              // the field name, or the parameter position such as `arg0`
+	All      bool           `json:"all,omitempty"`      // whether every binding of the type is injected as a slice or map
+	Optional bool           `json:"optional,omitempty"` // whether the field is left empty when there is no binding
+}
+
+// Graph returns the dependency graph of the bindings visible to the injector, including those inherited by a scope.
+// Dependencies are read from the parameters of each provider and, when a provider returns a pointer to a struct
+// (or the binding was made with Bind), from its tagged fields following the same rules as Fill.
+// Dependencies without a binding appear as nodes marked Missing. Invalid tags and fields are left out, use Validate to find them.
+func (injector *Injector) Graph() *DependencyGraph {
+	if injector.isVerbose() {
+		injector.logCall("Graph")
+	}
+
+	return injector.graph()
+}
+
+// graph builds the dependency graph of the bindings visible to the injector.
+func (injector *Injector) graph() *DependencyGraph {
+	bindings := injector.visibleBindings()
+	graph := &DependencyGraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+
+	// missing dependencies are listed after every binding
+	var missing []GraphNode
+	seen := map[string]bool{}
+
+	// edge adds an edge to the binding of a type and name, or to a missing node if there is none
+	edge := func(from string, typ reflect.Type, name string, e GraphEdge) {
+		e.From = from
+		e.To = nodeID(typ, name)
+		if _, exist := injector.lookup(typ, name); !exist {
+			if e.Optional {
+				return
+			}
+			if !seen[e.To] {
+				seen[e.To] = true
+				missing = append(missing, GraphNode{ID: e.To, Type: typ, TypeName: fullyQualifiedTypeString(typ), Name: name, Missing: true})
+			}
+		}
+		graph.Edges = append(graph.Edges, e)
+	}
+
+	// allEdges adds an edge to every binding of the element type of a slice or map type
+	allEdges := func(from string, typ reflect.Type, e GraphEdge) {
+		e.From = from
+		e.All = true
+		for _, b := range injector.all(typ.Elem()) {
+			e.To = nodeID(typ.Elem(), b.name)
+			graph.Edges = append(graph.Edges, e)
+		}
+	}
+
+	for _, typ := range sortedTypes(bindings) {
+		for _, name := range sortedNames(bindings[typ]) {
+			b := bindings[typ][name]
+			providerType := reflect.TypeOf(b.provider)
+
+			id := nodeID(typ, name)
+			graph.Nodes = append(graph.Nodes, GraphNode{ID: id, Type: typ, TypeName: fullyQualifiedTypeString(typ), Name: name, Lifetime: b.btype.String(), Site: b.site})
+
+			for i := 0; i < providerType.NumIn(); i++ {
+				e := GraphEdge{Kind: DependencyParam, Label: fmt.Sprintf("arg%d", i)}
+				if providerType.In(i) == contextType {
+					continue
+				}
+				if isMultiType(providerType.In(i)) {
+					allEdges(id, providerType.In(i), e)
+				} else {
+					edge(id, providerType.In(i), "", e)
+				}
+			}
+
+			if structType := b.filledStruct(); structType != nil {
+				for i := 0; i < structType.NumField(); i++ {
+					field := structType.Field(i)
+					tag, tagged, valid := parseTag(field)
+					if !tagged || !valid {
+						continue
+					}
+
+					e := GraphEdge{Kind: DependencyField, Label: field.Name, Optional: tag.optional}
+					if tag.all {
+						if isMultiType(field.Type) {
+							allEdges(id, field.Type, e)
+						}
+						continue
+					}
+					if field.Type.Kind() != reflect.Ptr && field.Type.Kind() != reflect.Interface {
+						continue
+					}
+					edge(id, field.Type, tag.name, e)
+				}
+			}
+		}
+	}
+	graph.Nodes = append(graph.Nodes, missing...)
+
+	return graph
+}
+
+// nodeID identifies the node of a type and binding name.
+func nodeID(typ reflect.Type, name string) string {
+	if name == "" {
+		return fullyQualifiedTypeString(typ)
+	}
+	return fmt.Sprintf("%s[%s]", fullyQualifiedTypeString(typ), name)
+}
+
+// label returns the text describing a node in the diagrams.
+func (n GraphNode) label() []string {
+	lines := []string{n.TypeName}
+	if n.Name != "" {
+		lines = append(lines, fmt.Sprintf("name: %s", n.Name))
+	}
+	if n.Missing {
+		lines = append(lines, "missing")
+	} else {
+		lines = append(lines, n.Lifetime)
+	}
+	return lines
+}
+
+// label returns the text describing an edge in the diagrams.
+func (e GraphEdge) label() string {
+	label := e.Label
+	if e.All {
+		label += " (all)"
+	}
+	if e.Optional {
+		label += " (optional)"
+	}
+	return label
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language.
+func (g *DependencyGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph di {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		attrs := fmt.Sprintf("label=%s", strconv.Quote(strings.Join(n.label(), "\n")))
+		if n.Missing {
+			attrs += ", style=dashed, color=red"
+		}
+		if n.Site != "" {
+			attrs += fmt.Sprintf(", tooltip=%s", strconv.Quote(n.Site))
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", strconv.Quote(n.ID), attrs)
+	}
+	for _, e := range g.Edges {
+		attrs := fmt.Sprintf("label=%s", strconv.Quote(e.label()))
+		if e.Optional {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), attrs)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart.
+func (g *DependencyGraph) WriteMermaid(w io.Writer) error {
+	ids := make(map[string]string, len(g.Nodes))
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[n.ID], mermaidEscape(strings.Join(n.label(), "<br/>")))
+		if n.Missing {
+			fmt.Fprintf(&b, "  style %s stroke:#f00,stroke-dasharray:5\n", ids[n.ID])
+		}
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Optional {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s|\"%s\"| %s\n", ids[e.From], arrow, mermaidEscape(e.label()), ids[e.To])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidEscape escapes the characters that cannot appear within a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, "\"", "#quot;")
+}
+
+// WriteJSON writes the graph as indented JSON.
+func (g *DependencyGraph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g)
+}
+
+// dependents returns the nodes that depend on a node, directly or through other nodes.
+func (g *DependencyGraph) dependents(id string) []GraphNode {
+	nodes := make(map[string]GraphNode, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+
+	var dependents []GraphNode
+	visited := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		to := queue[0]
+		queue = queue[1:]
+		for _, e := range g.Edges {
+			if e.To != to || visited[e.From] {
+				continue
+			}
+			visited[e.From] = true
+			dependents = append(dependents, nodes[e.From])
+			queue = append(queue, e.From)
+		}
+	}
+	return dependents
+}
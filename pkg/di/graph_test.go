@@ -0,0 +1,115 @@
+package di
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjector_Graph(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() *A {
+		return &A{}
+	})
+	injector.Instance(func(a *A, plugins []Plugin) *PluginHost {
+		return &PluginHost{}
+	})
+	injector.NamedSingleton("a", func() Plugin {
+		return &NamedPlugin{name: "a"}
+	})
+	injector.NamedSingleton("b", func() Plugin {
+		return &NamedPlugin{name: "b"}
+	})
+	Bind[Notifier, *EmailNotifier](injector, AsScoped())
+
+	graph := injector.Graph()
+	const pkg = "github.com/thinkdata-works/godi/pkg/"
+
+	ids := make([]string, len(graph.Nodes))
+	for i, n := range graph.Nodes {
+		ids[i] = n.ID
+	}
+	assert.Equal(t, []string{"*di.A", "*di.PluginHost", pkg + "di.Notifier", pkg + "di.Plugin[a]", pkg + "di.Plugin[b]", "*di.B", pkg + "di.Plugin", pkg + "di.Database"}, ids)
+
+	assert.Equal(t, "singleton", graph.Nodes[0].Lifetime)
+	assert.Contains(t, graph.Nodes[0].Site, "graph_test.go")
+	assert.Equal(t, "scoped", graph.Nodes[2].Lifetime)
+	assert.Equal(t, "a", graph.Nodes[3].Name)
+	for _, n := range graph.Nodes[5:] {
+		assert.True(t, n.Missing)
+		assert.Empty(t, n.Lifetime)
+	}
+
+	assert.Equal(t, []GraphEdge{
+		{From: "*di.A", To: "*di.B", Kind: DependencyField, Label: "B"},
+		{From: "*di.PluginHost", To: "*di.A", Kind: DependencyParam, Label: "arg0"},
+		{From: "*di.PluginHost", To: pkg + "di.Plugin[a]", Kind: DependencyParam, Label: "arg1", All: true},
+		{From: "*di.PluginHost", To: pkg + "di.Plugin[b]", Kind: DependencyParam, Label: "arg1", All: true},
+		{From: "*di.PluginHost", To: pkg + "di.Plugin[a]", Kind: DependencyField, Label: "List", All: true},
+		{From: "*di.PluginHost", To: pkg + "di.Plugin[b]", Kind: DependencyField, Label: "List", All: true},
+		{From: "*di.PluginHost", To: pkg + "di.Plugin[a]", Kind: DependencyField, Label: "ByName", All: true},
+		{From: "*di.PluginHost", To: pkg + "di.Plugin[b]", Kind: DependencyField, Label: "ByName", All: true},
+		{From: "*di.PluginHost", To: pkg + "di.Plugin", Kind: DependencyField, Label: "Default"},
+		{From: pkg + "di.Notifier", To: pkg + "di.Database", Kind: DependencyField, Label: "DB"},
+	}, graph.Edges)
+
+	// scopes include the inherited bindings, and their own overrides
+	scope := injector.NewScope()
+	scope.Scoped(func() Database {
+		return &MySQL{}
+	})
+	scoped := scope.Graph()
+	assert.Len(t, scoped.Nodes, len(graph.Nodes))
+	assert.Equal(t, pkg+"di.Database", scoped.Nodes[2].ID)
+	assert.Equal(t, "scoped", scoped.Nodes[2].Lifetime)
+	assert.Equal(t, pkg+"di.Plugin", scoped.Nodes[7].ID)
+}
+
+func TestInjector_Graph_Writers(t *testing.T) {
+	var injector = NewInjector()
+	injector.Singleton(func() *A {
+		return &A{}
+	})
+	injector.NamedInstance("primary", func(a *A) *B {
+		return &B{}
+	})
+
+	var dot bytes.Buffer
+	assert.NoError(t, injector.Graph().WriteDOT(&dot))
+	assert.Contains(t, dot.String(), "digraph di {")
+	assert.Contains(t, dot.String(), `"*di.A" [label="*di.A\nsingleton"`)
+	assert.Contains(t, dot.String(), `"*di.B[primary]" [label="*di.B\nname: primary\ninstance"`)
+	assert.Contains(t, dot.String(), `"*di.B" [label="*di.B\nmissing", style=dashed, color=red];`)
+	assert.Contains(t, dot.String(), `"*di.A" -> "*di.B" [label="B"];`)
+	assert.Contains(t, dot.String(), `"*di.B[primary]" -> "*di.A" [label="arg0"];`)
+
+	var mermaid bytes.Buffer
+	assert.NoError(t, injector.Graph().WriteMermaid(&mermaid))
+	assert.Contains(t, mermaid.String(), "flowchart LR\n")
+	assert.Contains(t, mermaid.String(), `n0["*di.A<br/>singleton"]`)
+	assert.Contains(t, mermaid.String(), `n1["*di.B<br/>name: primary<br/>instance"]`)
+	assert.Contains(t, mermaid.String(), `n0 -->|"B"| n2`)
+	assert.Contains(t, mermaid.String(), `n1 -->|"arg0"| n0`)
+
+	var buf bytes.Buffer
+	assert.NoError(t, injector.Graph().WriteJSON(&buf))
+	var decoded struct {
+		Nodes []map[string]interface{} `json:"nodes"`
+		Edges []map[string]interface{} `json:"edges"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	if assert.Len(t, decoded.Nodes, 4) {
+		assert.Equal(t, "*di.B[primary]", decoded.Nodes[1]["id"])
+		assert.Equal(t, "*di.B", decoded.Nodes[1]["type"])
+		assert.Equal(t, "primary", decoded.Nodes[1]["name"])
+		assert.Equal(t, "instance", decoded.Nodes[1]["lifetime"])
+		assert.Equal(t, true, decoded.Nodes[2]["missing"])
+		assert.Equal(t, "*di.C", decoded.Nodes[3]["id"])
+	}
+	assert.Len(t, decoded.Edges, 3)
+}
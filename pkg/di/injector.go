@@ -1,26 +1,26 @@
 package di
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 	"unsafe"
-
-	"github.com/fatih/color"
 )
 
 const (
-	tagName         = "di"
-	injectByType    = "type"
-	injectByName    = "name"
-	bindingPrefix   = "BINDING"
-	resolvingPrefix = "RESOLVING"
-	returningPrefix = "RETURNING"
-	invokingPrefix  = "INVOKING"
-	fillingPrefix   = "FILLING"
+	tagName        = "di"
+	injectByType   = "type"
+	injectByName   = "name"
+	injectAll      = "all"
+	injectSkip     = "-"
+	injectOptional = "optional"
 )
 
 type bindingtype int
@@ -28,85 +28,270 @@ type bindingtype int
 const (
 	Binding_Instance bindingtype = iota
 	Binding_Singleton
+	Binding_Scoped
 )
 
+func (t bindingtype) String() string {
+	switch t {
+	case Binding_Instance:
+		return "instance"
+	case Binding_Singleton:
+		return "singleton"
+	case Binding_Scoped:
+		return "scoped"
+	}
+	return fmt.Sprintf("bindingtype(%d)", int(t))
+}
+
 // binding holds a binding provider and an instance (for singleton bindings).
 type binding struct {
-	provider interface{} // provider function that creates the appropriate implementation of the related abstraction
-	mu       *sync.Mutex // mutex for retrieving a singleton at evaluation time
-	instance interface{} // instance stored for reusing in singleton bindings
-	btype    bindingtype // type of the binding (singleton or instance)
+	provider   interface{}                                           // provider function that creates the appropriate implementation of the related abstraction
+	name       string                                                // name the binding is registered under, empty for unnamed bindings
+	mu         contextMutex                                          // mutex for retrieving a singleton at evaluation time
+	instance   interface{}                                           // instance stored for reusing in singleton bindings
+	created    *atomic.Bool                                          // set while the binding holds an instance, read without locking by introspection
+	closed     *atomic.Bool                                          // set once the instance is released by Close, shared with the copies holding it
+	id         uint64                                                // registration number, kept by the copies of the binding
+	seq        uint64                                                // sequence number ordering multibindings, kept by replacements
+	btype      bindingtype                                           // type of the binding (singleton, scoped or instance)
+	owner      *Injector                                             // injector the binding is registered in
+	site       string                                                // file:line the binding was registered from
+	impl       reflect.Type                                          // type created by a Bind provider whose fields are filled, nil for other providers
+	decorators *decorators                                           // decorators applied to the instances, shared with the copies of the binding
+	timeout    time.Duration                                         // bound on each resolution of the binding, zero for none
+	onClose    func(ctx context.Context, instance interface{}) error // hook releasing a singleton instance when the injector is closed
+	lazy       bool                                                  // whether Start skips creating the singleton instance
+	started    *atomic.Bool                                          // set once Start is called on the instance, shared with the copies holding it
+}
+
+// bindingSeq numbers bindings in the order they are registered.
+var bindingSeq uint64
+
+// newBinding creates a binding of the given type and applies the registration options to it.
+func newBinding(provider interface{}, name string, btype bindingtype, options []BindingOption) *binding {
+	id := atomic.AddUint64(&bindingSeq, 1)
+	b := &binding{provider: provider, name: name, id: id, seq: id, mu: newContextMutex(), created: new(atomic.Bool), btype: btype, decorators: &decorators{}}
+	for _, option := range options {
+		option(b)
+	}
+	return b
+}
+
+// filledStruct returns the struct type whose tagged fields are filled once the provider returns,
+// nil if it cannot be known without invoking the provider.
+func (b *binding) filledStruct() reflect.Type {
+	typ := b.impl
+	if typ == nil {
+		typ = reflect.TypeOf(b.provider).Out(0)
+	}
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	return typ.Elem()
+}
+
+// pendingInstance marks a binding whose provider is currently being invoked within a resolution call.
+type pendingInstance struct{}
+
+// resolution holds the state shared by everything resolved within a single resolution call.
+type resolution struct {
+	ctx          context.Context             // context of the resolution call, passed to providers taking one
+	instantiated map[instanceKey]interface{} // instances created so far, used to resolve circular dependencies
+	path         []reflect.Type              // chain of types currently being resolved
+	sites        []string                    // registration sites of the bindings in path
+}
+
+// instanceKey identifies an instance created within a resolution call. Instances are keyed by the injector resolving
+// them, as a scope and the injector a singleton is registered in resolve scoped bindings to different instances.
+type instanceKey struct {
+	injector *Injector
+	provider reflect.Type
+	name     string
+}
+
+func newResolution(ctx context.Context) *resolution {
+	return &resolution{
+		ctx:          ctx,
+		instantiated: make(map[instanceKey]interface{}),
+	}
 }
 
-// resolve creates an appropriate implementation of the related abstraction
-func (b *binding) resolve(injector *Injector, name string, instantiated map[reflect.Type]map[string]interface{}) (interface{}, error) {
+// canceled returns the error aborting the resolution of a binding once the context is done.
+func (r *resolution) canceled(b *binding, name string, err error) error {
+	return &CanceledError{Type: reflect.TypeOf(b.provider).Out(0), Name: name, Site: b.site, Path: r.chain(), Err: err}
+}
+
+// chain returns a copy of the chain of types currently being resolved.
+func (r *resolution) chain() []reflect.Type {
+	return append([]reflect.Type(nil), r.path...)
+}
+
+// site returns the registration site of the binding currently being resolved, empty outside of a binding.
+func (r *resolution) site() string {
+	if len(r.sites) == 0 {
+		return ""
+	}
+	return r.sites[len(r.sites)-1]
+}
+
+// resolve creates an appropriate implementation of the related abstraction, and records the resolution in the metrics.
+func (b *binding) resolve(injector *Injector, name string, state *resolution) (interface{}, error) {
+	instance, err := b.resolveInstance(injector, name, state)
+
+	typ := reflect.TypeOf(b.provider).Out(0)
+	injector.sinks(func(sink MetricsSink) {
+		sink.Resolved(typ, name, err)
+	})
+	if err != nil {
+		return nil, injector.failed(typ, name, err)
+	}
+	return instance, nil
+}
+
+// resolveInstance returns the instance of the binding, invoking the provider unless a singleton or scoped instance exists.
+func (b *binding) resolveInstance(injector *Injector, name string, state *resolution) (interface{}, error) {
 
 	providerType := reflect.TypeOf(b.provider)
 
 	if injector.isVerbose() {
 		injector.incrementLoggerIndent()
 		defer injector.decrementLoggerIndent()
+	}
 
-		injector.logDebug(fmt.Sprintf("%s: provider for type `%s`", color.MagentaString(resolvingPrefix), color.BlueString(fullyQualifiedTypeString(providerType.Out(0)))))
+	if injector.observed() {
+		injector.report(ResolveStarted{Type: providerType.Out(0), Name: name, Lifetime: b.btype.String(), Site: b.site, Depth: len(state.path)})
 	}
 
-	// resolve circular dependencies within a resolution call
-	instances, instantiatedTypeAlready := instantiated[providerType]
-	if !instantiatedTypeAlready {
-		instantiated[providerType] = make(map[string]interface{})
-		instances, _ = instantiated[providerType]
+	// resolve circular dependencies within a resolution call, singletons being shared by every scope
+	key := instanceKey{injector: injector, provider: providerType, name: name}
+	if b.btype == Binding_Singleton {
+		key.injector = b.owner
 	}
 
-	instance, instantiatedAlready := instances[name]
+	instance, instantiatedAlready := state.instantiated[key]
 	if instantiatedAlready {
+		if _, pending := instance.(pendingInstance); pending {
+			// the provider is still being invoked further up the stack, so one of its arguments depends on it
+			return nil, injector.errorMiddleWare(&CircularDependencyError{Type: providerType.Out(0), Name: name, Site: b.site, Path: state.chain()})
+		}
 		return instance, nil
 	}
 
-	if b.btype == Binding_Singleton {
+	state.path = append(state.path, providerType.Out(0))
+	state.sites = append(state.sites, b.site)
+	defer func() {
+		state.path = state.path[:len(state.path)-1]
+		state.sites = state.sites[:len(state.sites)-1]
+	}()
+
+	if b.timeout > 0 {
+		ctx, cancel := context.WithTimeout(state.ctx, b.timeout)
+		defer cancel()
+
+		// the dependencies of the binding are resolved within its timeout
+		defer func(parent context.Context) {
+			state.ctx = parent
+		}(state.ctx)
+		state.ctx = ctx
+	}
+
+	if err := state.ctx.Err(); err != nil {
+		return nil, injector.errorMiddleWare(state.canceled(b, name, err))
+	}
+
+	if b.btype == Binding_Singleton || b.btype == Binding_Scoped {
+		if b.btype == Binding_Singleton {
+			// singletons are shared by every scope, so their dependencies come from the injector they are registered in
+			injector = b.owner
+		} else {
+			// scoped bindings are shared within a scope, each scope holds its own copy of the binding
+			b = injector.scopedBinding(b)
+		}
+
 		// we may have two callers try to resolve the singleton at once, which could create two instances of it
 		// access the lock before checking if an instance is defined. If it is, release lock and return
 		// otherwise, create a new one, set it, release the lock and return
 		if injector.isVerbose() {
-			injector.logDebug(fmt.Sprintf("%s: attempting to access instance for singleton `%s`", color.MagentaString(returningPrefix), color.YellowString(fmt.Sprintf("%+v", b))))
+			injector.logDebug(eventReturn, fmt.Sprintf("attempting to access instance for %s `%s`", b.btype, fullyQualifiedTypeString(providerType)), b.attrs()...)
 		}
 
-		b.mu.Lock()
+		// waiting for another caller creating the instance is abandoned once the context is done
+		if !b.mu.TryLock() {
+			start := time.Now()
+			err := b.mu.LockContext(state.ctx)
+			elapsed := time.Since(start)
+			injector.sinks(func(sink MetricsSink) {
+				sink.LockWaited(providerType.Out(0), name, elapsed)
+			})
+			if err != nil {
+				return nil, injector.errorMiddleWare(state.canceled(b, name, err))
+			}
+		}
 		defer b.mu.Unlock()
 		if b.instance == nil {
 
 			if injector.isVerbose() {
-				injector.logDebug(fmt.Sprintf("%s: invoking provider to create singleton instance", color.MagentaString(returningPrefix)))
+				injector.logDebug(eventReturn, "invoking provider to create singleton instance", b.attrs()...)
 			}
 
-			instance, err := injector.invoke(b.provider)
+			state.instantiated[key] = pendingInstance{}
+			instance, err := injector.invoke(b, name, state)
 			if err != nil {
+				delete(state.instantiated, key)
 				return nil, err
 			}
 
-			instances[name] = instance
+			state.instantiated[key] = instance
+
+			err = injector.fill(instance, state)
+			if err != nil {
+				return nil, err
+			}
 
-			err = injector.fill(instance, instantiated)
+			instance, err = injector.decorate(b, instance, name, state)
 			if err != nil {
 				return nil, err
 			}
+			state.instantiated[key] = instance
 
 			b.instance = instance
+			b.created.Store(true)
+			b.closed = new(atomic.Bool)
+			b.started = new(atomic.Bool)
+			injector.track(b)
+
+			if injector.observed() {
+				injector.report(InstanceCreated{Type: providerType.Out(0), Name: name, Lifetime: b.btype.String(), Instance: instance})
+			}
 		}
 
 		return b.instance, nil
 	}
 
-	instance, err := injector.invoke(b.provider)
+	state.instantiated[key] = pendingInstance{}
+	instance, err := injector.invoke(b, name, state)
 	if err != nil {
+		delete(state.instantiated, key)
 		return nil, err
 	}
 
-	instances[name] = instance
+	state.instantiated[key] = instance
 
-	err = injector.fill(instance, instantiated)
+	err = injector.fill(instance, state)
 	if err != nil {
 		return nil, err
 	}
+
+	instance, err = injector.decorate(b, instance, name, state)
+	if err != nil {
+		return nil, err
+	}
+	state.instantiated[key] = instance
+
+	if injector.observed() {
+		injector.report(InstanceCreated{Type: providerType.Out(0), Name: name, Lifetime: b.btype.String(), Instance: instance})
+	}
+
 	return instance, nil
 }
 
@@ -114,19 +299,41 @@ type errorHandler func(error)
 
 // Injector holds all of the declared bindings
 type Injector struct {
-	bindings      map[reflect.Type]map[string]*binding
-	verbose       int32
-	verboseIndent int32
-	errHandler    errorHandler
-	logger        *log.Logger
-	mu            *sync.RWMutex
+	bindings        *atomic.Pointer[map[reflect.Type]map[string]*binding] // snapshot of the bindings, replaced as a whole on registration
+	bindMu          *sync.Mutex                                           // mutex serializing changes to the bindings
+	parent          *Injector                                             // injector the scope was created from, nil for root injectors
+	scoped          map[*binding]*binding                                 // copies of scoped bindings holding the instances of this scope
+	scopedMu        *sync.Mutex                                           // mutex for the scoped bindings
+	verbose         int32
+	verboseIndent   int32
+	errHandler      errorHandler
+	logger          *log.Logger
+	slogger         *slog.Logger     // structured logger set with SetSlogLogger, nil for the pretty output to logger
+	observers       []*observerEntry // observers added with AddObserver, replaced as a whole on change
+	mu              *sync.RWMutex
+	frozen          int32       // whether changes to the bindings are rejected
+	overwritePolicy int32       // OverwritePolicy applied when a registration replaces a binding
+	timeout         int64       // time.Duration bounding each resolution call, zero for none
+	slowThreshold   int64       // time.Duration above which providers are reported as slow, zero for none
+	metrics         *MemorySink // measurements returned by Stats, shared with the scopes
+	sink            MetricsSink // additional sink receiving the measurements, nil for none
+	created         []*binding  // singleton bindings in the order their instances were created
+	createdMu       *sync.Mutex // mutex for the created singletons
 }
 
 // NewInjector creates a new instance of the Injector
 func NewInjector() *Injector {
+	bindings := &atomic.Pointer[map[reflect.Type]map[string]*binding]{}
+	bindings.Store(&map[reflect.Type]map[string]*binding{})
+
 	return &Injector{
-		bindings:      make(map[reflect.Type]map[string]*binding),
+		bindings:      bindings,
+		bindMu:        &sync.Mutex{},
 		mu:            &sync.RWMutex{},
+		scoped:        make(map[*binding]*binding),
+		scopedMu:      &sync.Mutex{},
+		createdMu:     &sync.Mutex{},
+		metrics:       NewMemorySink(),
 		verbose:       0,
 		verboseIndent: 0,
 		errHandler:    nil,
@@ -192,154 +399,224 @@ func debugTypeString(arg interface{}) string {
 func debugNameString(arg interface{}) string {
 	typeOf := reflect.TypeOf(arg)
 	if typeOf == nil {
-		return color.RedString("nil") + color.CyanString(") (") + color.YellowString("You need to pass by reference: i.e. injector.Resolve(&arg)")
+		return "nil) (You need to pass by reference: i.e. injector.Resolve(&arg)"
 	}
 	return fullyQualifiedTypeString(typeOf)
 }
 
-func (injector *Injector) getLogPrefix() string {
-	prefix := "di: "
-	indent := atomic.LoadInt32(&injector.verboseIndent)
-	for i := int32(0); i < indent; i++ {
-		if i == indent-1 {
-			prefix += "╰-> "
-		} else {
-			prefix += "    "
-		}
-	}
-	return prefix
-}
-
 func (injector *Injector) errorMiddleWare(err error) error {
 	if injector.isVerbose() {
 		injector.incrementLoggerIndent()
 		defer injector.decrementLoggerIndent()
 
-		errStr := fmt.Sprintf("%s: %s", color.RedString("ERROR"), err.Error())
+		injector.log(slog.LevelError, eventError, err.Error(), []slog.Attr{slog.Any(ErrorKey, err)})
+	}
+	return err
+}
 
-		injector.mu.RLock()
-		defer injector.mu.RUnlock()
+// loadBindings returns the current snapshot of the bindings, it must not be modified.
+func (injector *Injector) loadBindings() map[reflect.Type]map[string]*binding {
+	return *injector.bindings.Load()
+}
 
-		if injector.logger != nil {
-			injector.logger.Print(injector.getLogPrefix() + errStr)
-		} else {
-			fmt.Println(injector.getLogPrefix() + errStr)
-		}
+// writeBindings applies changes to a copy of the bindings and publishes it as the new snapshot.
+// Registrations are serialized, while resolutions keep reading their snapshot without taking a lock.
+// Nothing is published if write returns an error.
+func (injector *Injector) writeBindings(write func(bindings map[reflect.Type]map[string]*binding) error) error {
+	injector.bindMu.Lock()
+	defer injector.bindMu.Unlock()
+
+	current := injector.loadBindings()
+	next := make(map[reflect.Type]map[string]*binding, len(current))
+	for typ, names := range current {
+		next[typ] = names
 	}
-	return err
+
+	if err := write(next); err != nil {
+		return err
+	}
+
+	injector.bindings.Store(&next)
+	return nil
 }
 
-func (injector *Injector) logDebug(str string) {
-	injector.mu.RLock()
-	defer injector.mu.RUnlock()
+// setBinding sets or, when b is nil, deletes a binding within writeBindings.
+// The names of the type are copied first since they are shared with previous snapshots.
+func setBinding(bindings map[reflect.Type]map[string]*binding, typ reflect.Type, name string, b *binding) {
+	names := make(map[string]*binding, len(bindings[typ])+1)
+	for n, existing := range bindings[typ] {
+		names[n] = existing
+	}
 
-	if injector.logger != nil {
-		injector.logger.Print(injector.getLogPrefix() + str)
+	if b == nil {
+		delete(names, name)
 	} else {
-		fmt.Println(injector.getLogPrefix() + str)
+		names[name] = b
+	}
+
+	if len(names) == 0 {
+		delete(bindings, typ)
+	} else {
+		bindings[typ] = names
 	}
 }
 
-func (injector *Injector) get(typ reflect.Type, name string) interface{} {
+// lookup finds the binding for a type and name, falling back to the parent injectors of a scope.
+func (injector *Injector) lookup(typ reflect.Type, name string) (*binding, bool) {
+	for i := injector; i != nil; i = i.parent {
+		if concrete, exist := i.loadBindings()[typ][name]; exist {
+			return concrete, true
+		}
+	}
+	return nil, false
+}
 
-	concrete, exist := injector.bindings[typ][name]
-	if !exist {
-		injector.handleError(fmt.Errorf("no provider found for argument of type `%s`, ensure the type provided matches the return value of the provider", fullyQualifiedTypeString(typ)))
-		return nil
+func (injector *Injector) get(ctx context.Context, typ reflect.Type, name string) (interface{}, error) {
+	state, cancel := injector.startResolution(ctx)
+	defer cancel()
+
+	if isMultiType(typ) {
+		if name != "" {
+			return nil, injector.errorMiddleWare(&InvalidArgumentError{Type: typ, Reason: "slices and maps collect every binding of a type and cannot be resolved by name"})
+		}
+
+		all, err := injector.resolveAll(typ, state)
+		if err != nil {
+			return nil, err
+		}
+		return all.Interface(), nil
 	}
 
-	instance, err := concrete.resolve(injector, name, map[reflect.Type]map[string]interface{}{})
-	if err != nil {
-		injector.handleError(err)
-		return nil
+	if typ.Kind() != reflect.Interface && typ.Kind() != reflect.Ptr {
+		return nil, injector.errorMiddleWare(&InvalidArgumentError{Type: typ, Reason: fmt.Sprintf("type must be either a pointer, an interface, or a slice or map of them, not: %s", typ.Kind())})
 	}
 
-	return instance
+	concrete, exist := injector.lookup(typ, name)
+	if !exist {
+		return nil, injector.failed(typ, name, injector.errorMiddleWare(&MissingProviderError{Type: typ, Name: name}))
+	}
+
+	return concrete.resolve(injector, name, state)
 }
 
 // bind maps an abstraction to a concrete and sets an instance if it's a singleton binding.
-func (injector *Injector) bind(provider interface{}, name string, singleton bool) error {
+func (injector *Injector) bind(provider interface{}, name string, btype bindingtype, options []BindingOption) error {
 	if injector.isVerbose() {
 		injector.incrementLoggerIndent()
 		defer injector.decrementLoggerIndent()
 	}
 
-	providerType := reflect.TypeOf(provider)
-	if providerType.Kind() != reflect.Func {
-		return injector.errorMiddleWare(errors.New("provider argument must be a function"))
+	// options may override the name and lifetime of the binding
+	settings := &binding{name: name, btype: btype}
+	for _, option := range options {
+		option(settings)
 	}
+	name, btype = settings.name, settings.btype
+	site := callerSite()
 
-	if providerType.NumIn() != 0 {
-		return injector.errorMiddleWare(fmt.Errorf("provider function signature of `%s` is invalid, arguments are not permitted to providers", fullyQualifiedTypeString(providerType)))
+	providerType, err := injector.checkProvider(provider, name)
+	if err != nil {
+		return err
 	}
 
-	if providerType.NumOut() != 1 && providerType.NumOut() != 2 {
-		return injector.errorMiddleWare(fmt.Errorf("provider function signature of `%s` is invalid, must return one or two values", fullyQualifiedTypeString(providerType)))
+	err = injector.writeBindings(func(bindings map[reflect.Type]map[string]*binding) error {
+		if injector.Frozen() {
+			return &FrozenError{Operation: "register", Type: providerType.Out(0), Name: name}
+		}
+
+		b := newBinding(provider, name, btype, options)
+		b.owner = injector
+		b.site = site
+
+		if err := injector.checkOverwrite(bindings, providerType.Out(0), b); err != nil {
+			return err
+		}
+
+		setBinding(bindings, providerType.Out(0), name, b)
+		return nil
+	})
+	if err != nil {
+		return injector.errorMiddleWare(err)
 	}
 
-	if providerType.Out(0).Kind() != reflect.Ptr && providerType.Out(0).Kind() != reflect.Interface {
-		return injector.errorMiddleWare(fmt.Errorf("provider function signature of `%s` is invalid, must return a pointer or interface type", fullyQualifiedTypeString(providerType)))
+	if injector.observed() {
+		injector.report(BindingRegistered{Type: providerType.Out(0), Name: name, Lifetime: btype.String(), Provider: providerType, Site: site})
 	}
 
-	for i := 0; i < providerType.NumOut(); i++ {
-		if _, exist := injector.bindings[providerType.Out(i)]; !exist {
-			injector.bindings[providerType.Out(i)] = make(map[string]*binding)
-		}
+	return nil
+}
+
+// checkProvider checks the signature of a provider function and returns its type.
+func (injector *Injector) checkProvider(provider interface{}, name string) (reflect.Type, error) {
+	providerType := reflect.TypeOf(provider)
+	if providerType == nil || providerType.Kind() != reflect.Func {
+		return nil, injector.errorMiddleWare(&InvalidProviderError{Provider: providerType, Name: name, Reason: fmt.Sprintf("not `%v`", provider)})
+	}
 
-		if injector.isVerbose() && i == 0 {
-			injector.logDebug(fmt.Sprintf("%s: provider for type `%s` with structure `%s`", color.MagentaString(bindingPrefix), color.BlueString(fullyQualifiedTypeString(providerType.Out(i))), color.GreenString(fullyQualifiedTypeString(providerType))))
+	for i := 0; i < providerType.NumIn(); i++ {
+		if providerType.In(i).Kind() != reflect.Ptr && providerType.In(i).Kind() != reflect.Interface && !isMultiType(providerType.In(i)) {
+			return nil, injector.errorMiddleWare(&InvalidProviderError{Provider: providerType, Name: name, Reason: fmt.Sprintf("argument `%s` must be a pointer or interface type, or a slice or map of them", fullyQualifiedTypeString(providerType.In(i)))})
 		}
+	}
 
-		if singleton {
-			if injector.isVerbose() && i == 0 {
-				injector.logDebug(fmt.Sprintf("%s: singleton provider for type `%s` with structure `%s`", color.MagentaString(bindingPrefix), color.BlueString(fullyQualifiedTypeString(providerType.Out(i))), color.GreenString(fullyQualifiedTypeString(providerType))))
-			}
+	if providerType.NumOut() != 1 && providerType.NumOut() != 2 {
+		return nil, injector.errorMiddleWare(&InvalidProviderError{Provider: providerType, Name: name, Reason: "must return one or two values"})
+	}
 
-			injector.bindings[providerType.Out(i)][name] = &binding{provider: provider, mu: &sync.Mutex{}, btype: Binding_Singleton}
-		} else {
-			if injector.isVerbose() && i == 0 {
-				injector.logDebug(fmt.Sprintf("%s: instance provider for type `%s` with structure `%s`", color.MagentaString(bindingPrefix), color.BlueString(fullyQualifiedTypeString(providerType.Out(i))), color.GreenString(fullyQualifiedTypeString(providerType))))
-			}
-			injector.bindings[providerType.Out(i)][name] = &binding{provider: provider, btype: Binding_Instance}
-		}
+	if providerType.Out(0).Kind() != reflect.Ptr && providerType.Out(0).Kind() != reflect.Interface {
+		return nil, injector.errorMiddleWare(&InvalidProviderError{Provider: providerType, Name: name, Reason: "must return a pointer or interface type"})
 	}
 
-	return nil
+	if providerType.NumOut() == 2 && providerType.Out(1) != reflect.TypeFor[error]() {
+		return nil, injector.errorMiddleWare(&InvalidProviderError{Provider: providerType, Name: name, Reason: "second return value must be an error"})
+	}
+
+	return providerType, nil
 }
 
-// invoke calls a function and returns the yielded value.
-// It only works for functions that return a single value.
-func (injector *Injector) invoke(function interface{}) (interface{}, error) {
+// invoke calls a provider function, resolving its arguments from the bindings, and returns the yielded value.
+// It only works for functions that return one or two values.
+func (injector *Injector) invoke(b *binding, name string, state *resolution) (interface{}, error) {
+	function := b.provider
 	functionType := reflect.TypeOf(function)
 	if injector.isVerbose() {
-		injector.logDebug(fmt.Sprintf("%s: arguments for provider `%s`", color.MagentaString(resolvingPrefix), color.GreenString(fullyQualifiedTypeString(functionType))))
+		injector.logDebug(eventResolve, fmt.Sprintf("arguments for provider `%s`", fullyQualifiedTypeString(functionType)), typeAttrs(functionType.Out(0), name)...)
 	}
 
-	if injector.isVerbose() {
-		injector.logDebug(fmt.Sprintf("%s: provider `%s` for type `%s`", color.MagentaString(invokingPrefix), color.GreenString(fullyQualifiedTypeString(functionType)), color.BlueString(fullyQualifiedTypeString(functionType.Out(0)))))
+	args, err := injector.arguments(function, state)
+	if err != nil {
+		return nil, err
 	}
 
-	// no args
-	var args []reflect.Value
+	if injector.isVerbose() {
+		injector.logDebug(eventInvoke, fmt.Sprintf("provider `%s` for type `%s`", fullyQualifiedTypeString(functionType), fullyQualifiedTypeString(functionType.Out(0))), typeAttrs(functionType.Out(0), name)...)
+	}
 
 	if functionType.NumOut() == 1 {
 		if injector.isVerbose() {
 			injector.incrementLoggerIndent()
 		}
 
-		res := reflect.ValueOf(function).Call(args)[0].Interface()
+		values, elapsed := injector.callProvider(function, args, name, state)
+		res := values[0].Interface()
 
 		if injector.isVerbose() {
 			injector.decrementLoggerIndent()
 		}
 
-		resv := reflect.ValueOf(res)
-		if resv.Kind() != reflect.Struct && (res == nil || resv.IsNil()) {
-			return nil, injector.errorMiddleWare(fmt.Errorf("provider function returned a nil value"))
+		if injector.observed() {
+			injector.report(ProviderInvoked{Type: functionType.Out(0), Name: name, Provider: functionType, Value: res, Duration: elapsed})
 		}
 
-		if injector.isVerbose() {
-			injector.logDebug(fmt.Sprintf("%s: value %s", color.MagentaString(returningPrefix), color.YellowString(fmt.Sprintf("%+v", res))))
+		// a provider not watching the context may return after it is done, its value is then released and discarded
+		if err := expired(state.ctx); err != nil {
+			injector.discard(b, res, state)
+			return nil, injector.errorMiddleWare(&CanceledError{Type: functionType.Out(0), Name: name, Site: state.site(), Path: state.chain(), Err: err})
+		}
+
+		resv := reflect.ValueOf(res)
+		if resv.Kind() != reflect.Struct && (res == nil || resv.IsNil()) {
+			return nil, injector.errorMiddleWare(&ProviderFailedError{Type: functionType.Out(0), Name: name, Provider: functionType, Site: state.site(), Path: state.chain(), Err: errors.New("provider function returned a nil value")})
 		}
 
 		return res, nil
@@ -348,7 +625,7 @@ func (injector *Injector) invoke(function interface{}) (interface{}, error) {
 			injector.incrementLoggerIndent()
 		}
 
-		values := reflect.ValueOf(function).Call(args)
+		values, elapsed := injector.callProvider(function, args, name, state)
 		res := values[0].Interface()
 		var e error
 		if values[1].Interface() != nil {
@@ -358,30 +635,34 @@ func (injector *Injector) invoke(function interface{}) (interface{}, error) {
 		if injector.isVerbose() {
 			injector.decrementLoggerIndent()
 		}
+
+		if injector.observed() {
+			injector.report(ProviderInvoked{Type: functionType.Out(0), Name: name, Provider: functionType, Value: res, Err: e, Duration: elapsed})
+		}
+
 		if e != nil {
-			if injector.isVerbose() {
-				injector.logDebug(fmt.Sprintf("%s: value %s", color.MagentaString(returningPrefix), color.RedString(fmt.Sprintf("%+v", e))))
-			}
-			return nil, injector.errorMiddleWare(e)
+			return nil, injector.errorMiddleWare(&ProviderFailedError{Type: functionType.Out(0), Name: name, Provider: functionType, Site: state.site(), Path: state.chain(), Err: e})
 		}
 
-		resv := reflect.ValueOf(res)
-		if resv.Kind() != reflect.Struct && (res == nil || resv.IsNil()) {
-			return nil, injector.errorMiddleWare(fmt.Errorf("provider function returned a nil value"))
+		// a provider not watching the context may return after it is done, its value is then released and discarded
+		if err := expired(state.ctx); err != nil {
+			injector.discard(b, res, state)
+			return nil, injector.errorMiddleWare(&CanceledError{Type: functionType.Out(0), Name: name, Site: state.site(), Path: state.chain(), Err: err})
 		}
 
-		if injector.isVerbose() {
-			injector.logDebug(fmt.Sprintf("%s: value %s", color.MagentaString(returningPrefix), color.YellowString(fmt.Sprintf("%+v", res))))
+		resv := reflect.ValueOf(res)
+		if resv.Kind() != reflect.Struct && (res == nil || resv.IsNil()) {
+			return nil, injector.errorMiddleWare(&ProviderFailedError{Type: functionType.Out(0), Name: name, Provider: functionType, Site: state.site(), Path: state.chain(), Err: errors.New("provider function returned a nil value")})
 		}
 
 		return res, nil
 	}
 
-	return nil, injector.errorMiddleWare(errors.New("provider function signature is invalid, provider must return one or two values"))
+	return nil, injector.errorMiddleWare(&InvalidProviderError{Provider: functionType, Name: name, Reason: "must return one or two values"})
 }
 
 // arguments returns resolved arguments of a function.
-func (injector *Injector) arguments(function interface{}) ([]reflect.Value, error) {
+func (injector *Injector) arguments(function interface{}, state *resolution) ([]reflect.Value, error) {
 	functionType := reflect.TypeOf(function)
 	argumentsCount := functionType.NumIn()
 	arguments := make([]reflect.Value, argumentsCount)
@@ -389,12 +670,26 @@ func (injector *Injector) arguments(function interface{}) ([]reflect.Value, erro
 	for i := 0; i < argumentsCount; i++ {
 		abstraction := functionType.In(i)
 
-		concrete, exist := injector.bindings[abstraction][""]
+		if abstraction == contextType {
+			arguments[i] = reflect.ValueOf(&state.ctx).Elem()
+			continue
+		}
+
+		if isMultiType(abstraction) {
+			all, err := injector.resolveAll(abstraction, state)
+			if err != nil {
+				return nil, err
+			}
+			arguments[i] = all
+			continue
+		}
+
+		concrete, exist := injector.lookup(abstraction, "")
 		if !exist {
-			return nil, injector.errorMiddleWare(fmt.Errorf("no provider found for type `%s`", fullyQualifiedTypeString(abstraction)))
+			return nil, injector.failed(abstraction, "", injector.errorMiddleWare(&MissingProviderError{Type: abstraction, Site: state.site(), Path: state.chain()}))
 		}
 
-		instance, err := concrete.resolve(injector, "", map[reflect.Type]map[string]interface{}{})
+		instance, err := concrete.resolve(injector, "", state)
 		if err != nil {
 			return nil, err
 		}
@@ -407,12 +702,12 @@ func (injector *Injector) arguments(function interface{}) ([]reflect.Value, erro
 // Singleton binds an abstraction to concrete for further singleton resolves.
 // It takes a provider function that returns the concrete, and its return type matches the abstraction (interface).
 // The provider function can have arguments of abstraction that have been declared in the Injector already.
-func (injector *Injector) Singleton(provider interface{}) *Injector {
+func (injector *Injector) Singleton(provider interface{}, options ...BindingOption) *Injector {
 	if injector.isVerbose() {
-		injector.logDebug(fmt.Sprintf("%s%s%s", color.CyanString("Singleton("), color.GreenString(debugTypeString(provider)), color.CyanString(")")))
+		injector.logCall("Singleton", debugTypeString(provider))
 	}
 
-	err := injector.bind(provider, "", true)
+	err := injector.bind(provider, "", Binding_Singleton, options)
 	if err != nil {
 		injector.handleError(err)
 	}
@@ -421,12 +716,12 @@ func (injector *Injector) Singleton(provider interface{}) *Injector {
 }
 
 // NamedSingleton binds like the Singleton method but for named bindings.
-func (injector *Injector) NamedSingleton(name string, provider interface{}) *Injector {
+func (injector *Injector) NamedSingleton(name string, provider interface{}, options ...BindingOption) *Injector {
 	if injector.isVerbose() {
-		injector.logDebug(fmt.Sprintf("%s%s, %s%s", color.CyanString("NamedSingleton("), color.YellowString(fmt.Sprintf("\"%s\"", name)), color.GreenString(debugTypeString(provider)), color.CyanString(")")))
+		injector.logCall("NamedSingleton", fmt.Sprintf("%q", name), debugTypeString(provider))
 	}
 
-	err := injector.bind(provider, name, true)
+	err := injector.bind(provider, name, Binding_Singleton, options)
 	if err != nil {
 		injector.handleError(err)
 	}
@@ -437,12 +732,12 @@ func (injector *Injector) NamedSingleton(name string, provider interface{}) *Inj
 // Instance binds an abstraction to concrete for further transient resolves.
 // It takes a provider function that returns the concrete, and its return type matches the abstraction (interface).
 // The provider function can have arguments of abstraction that have been declared in the Injector already.
-func (injector *Injector) Instance(provider interface{}) *Injector {
+func (injector *Injector) Instance(provider interface{}, options ...BindingOption) *Injector {
 	if injector.isVerbose() {
-		injector.logDebug(fmt.Sprintf("%s%s%s", color.CyanString("Instance("), color.GreenString(debugTypeString(provider)), color.CyanString(")")))
+		injector.logCall("Instance", debugTypeString(provider))
 	}
 
-	err := injector.bind(provider, "", false)
+	err := injector.bind(provider, "", Binding_Instance, options)
 	if err != nil {
 		injector.handleError(err)
 	}
@@ -451,12 +746,12 @@ func (injector *Injector) Instance(provider interface{}) *Injector {
 }
 
 // NamedInstance binds like the Instance method but for named bindings.
-func (injector *Injector) NamedInstance(name string, provider interface{}) *Injector {
+func (injector *Injector) NamedInstance(name string, provider interface{}, options ...BindingOption) *Injector {
 	if injector.isVerbose() {
-		injector.logDebug(fmt.Sprintf("%s%s, %s%s", color.CyanString("NamedInstance("), color.YellowString(fmt.Sprintf("\"%s\"", name)), color.GreenString(debugTypeString(provider)), color.CyanString(")")))
+		injector.logCall("NamedInstance", fmt.Sprintf("%q", name), debugTypeString(provider))
 	}
 
-	err := injector.bind(provider, name, false)
+	err := injector.bind(provider, name, Binding_Instance, options)
 	if err != nil {
 		injector.handleError(err)
 	}
@@ -467,11 +762,21 @@ func (injector *Injector) NamedInstance(name string, provider interface{}) *Inje
 // Reset deletes all the existing bindings from the injector instance.
 func (injector *Injector) Reset() {
 	if injector.isVerbose() {
-		injector.logDebug(color.CyanString("Reset()"))
+		injector.logCall("Reset")
 	}
 
-	for k := range injector.bindings {
-		delete(injector.bindings, k)
+	err := injector.writeBindings(func(bindings map[reflect.Type]map[string]*binding) error {
+		if injector.Frozen() {
+			return &FrozenError{Operation: "reset"}
+		}
+
+		for typ := range bindings {
+			delete(bindings, typ)
+		}
+		return nil
+	})
+	if err != nil {
+		injector.handleError(injector.errorMiddleWare(err))
 	}
 }
 
@@ -479,25 +784,37 @@ func (injector *Injector) Reset() {
 // It invokes the function (receiver) and passes the related implementations.
 func (injector *Injector) Call(function interface{}) {
 	if injector.isVerbose() {
-		injector.logDebug(fmt.Sprintf("%s%s%s", color.CyanString("Call("), color.GreenString(debugTypeString(function)), color.CyanString(")")))
+		injector.logCall("Call", debugTypeString(function))
 	}
 
-	err := injector.call(function)
+	err := injector.call(context.Background(), function)
 	if err != nil {
 		injector.handleError(err)
 	}
 }
 
-func (injector *Injector) call(function interface{}) error {
+// TryCall calls like the Call method but returns any error instead of passing it to the error handler.
+func (injector *Injector) TryCall(function interface{}) error {
+	if injector.isVerbose() {
+		injector.logCall("TryCall", debugTypeString(function))
+	}
+
+	return injector.call(context.Background(), function)
+}
+
+func (injector *Injector) call(ctx context.Context, function interface{}) error {
 	receiverType := reflect.TypeOf(function)
 	if receiverType == nil {
-		return injector.errorMiddleWare(fmt.Errorf("invalid function argument `%v`", function))
+		return injector.errorMiddleWare(&InvalidArgumentError{Reason: "argument must be a function"})
 	}
 	if receiverType.Kind() != reflect.Func {
-		return injector.errorMiddleWare(fmt.Errorf("invalid function argument `%v` of type `%s`, argument must be a function", function, fullyQualifiedTypeString(receiverType)))
+		return injector.errorMiddleWare(&InvalidArgumentError{Type: receiverType, Reason: "argument must be a function"})
 	}
 
-	arguments, err := injector.arguments(function)
+	state, cancel := injector.startResolution(ctx)
+	defer cancel()
+
+	arguments, err := injector.arguments(function, state)
 	if err != nil {
 		return err
 	}
@@ -510,10 +827,10 @@ func (injector *Injector) call(function interface{}) error {
 // Resolve takes an abstraction (interface reference) and fills it with the related implementation.
 func (injector *Injector) Resolve(abstraction interface{}) {
 	if injector.isVerbose() {
-		injector.logDebug(fmt.Sprintf("%s%s%s", color.CyanString("Resolve("), color.BlueString(debugNameString(abstraction)), color.CyanString(")")))
+		injector.logCall("Resolve", debugNameString(abstraction))
 	}
 
-	err := injector.resolve(abstraction, "")
+	err := injector.resolve(context.Background(), abstraction, "")
 	if err != nil {
 		injector.handleError(err)
 		return
@@ -523,49 +840,84 @@ func (injector *Injector) Resolve(abstraction interface{}) {
 // NamedResolve resolves like the Resolve method but for named bindings.
 func (injector *Injector) NamedResolve(abstraction interface{}, name string) {
 	if injector.isVerbose() {
-		injector.logDebug(fmt.Sprintf("%s%s%s", color.CyanString("NamedResolve("), color.BlueString(debugNameString(abstraction)), color.CyanString(")")))
+		injector.logCall("NamedResolve", debugNameString(abstraction))
 	}
 
-	err := injector.resolve(abstraction, name)
+	err := injector.resolve(context.Background(), abstraction, name)
 	if err != nil {
 		injector.handleError(err)
 		return
 	}
 }
 
+// TryResolve resolves like the Resolve method but returns any error instead of passing it to the error handler.
+func (injector *Injector) TryResolve(abstraction interface{}) error {
+	if injector.isVerbose() {
+		injector.logCall("TryResolve", debugNameString(abstraction))
+	}
+
+	return injector.resolve(context.Background(), abstraction, "")
+}
+
+// TryNamedResolve resolves like the NamedResolve method but returns any error instead of passing it to the error handler.
+func (injector *Injector) TryNamedResolve(abstraction interface{}, name string) error {
+	if injector.isVerbose() {
+		injector.logCall("TryNamedResolve", debugNameString(abstraction))
+	}
+
+	return injector.resolve(context.Background(), abstraction, name)
+}
+
 func fullyQualifiedTypeString(t reflect.Type) string {
 	path := t.PkgPath()
 	if path == "" {
 		return t.String()
 	}
-	return fmt.Sprintf("%value.%s", path, t.Name())
+	return fmt.Sprintf("%s.%s", path, t.Name())
 }
 
-func (injector *Injector) resolve(abstraction interface{}, name string) error {
+func (injector *Injector) resolve(ctx context.Context, abstraction interface{}, name string) error {
+	state, cancel := injector.startResolution(ctx)
+	defer cancel()
+
 	receiverType := reflect.TypeOf(abstraction)
 	if receiverType == nil {
-		return injector.errorMiddleWare(fmt.Errorf("invalid abstraction argument `%+v`, ensure interface arguments are passed by reference (i.e Resolve(&arg))", abstraction))
+		return injector.errorMiddleWare(&InvalidArgumentError{Reason: "ensure interface arguments are passed by reference (i.e Resolve(&arg))"})
 	}
 
 	if receiverType.Kind() != reflect.Ptr {
-		return injector.errorMiddleWare(fmt.Errorf("invalid abstraction argument `%+v` of type `%s`, argument must be a struct or interface", abstraction, fullyQualifiedTypeString(receiverType)))
+		return injector.errorMiddleWare(&InvalidArgumentError{Type: receiverType, Reason: "argument must be a struct or interface"})
 	}
 
 	elem := receiverType.Elem()
+	if isMultiType(elem) {
+		if name != "" {
+			return injector.errorMiddleWare(&InvalidArgumentError{Type: elem, Reason: "slices and maps collect every binding of a type and cannot be resolved by name"})
+		}
+
+		all, err := injector.resolveAll(elem, state)
+		if err != nil {
+			return err
+		}
+
+		reflect.ValueOf(abstraction).Elem().Set(all)
+		return nil
+	}
+
 	if elem.Kind() != reflect.Struct && elem.Kind() != reflect.Interface && elem.Kind() != reflect.Ptr {
-		return injector.errorMiddleWare(fmt.Errorf("invalid abstraction argument `%+v` of type `%s`, argument must be a struct or interface", abstraction, fullyQualifiedTypeString(receiverType)))
+		return injector.errorMiddleWare(&InvalidArgumentError{Type: receiverType, Reason: "argument must be a struct or interface"})
 	}
 
-	concrete, exist := injector.bindings[elem][name]
+	concrete, exist := injector.lookup(elem, name)
 	if !exist {
-		concrete, exist = injector.bindings[receiverType][name]
+		_, exist = injector.lookup(receiverType, name)
 		if !exist {
-			return injector.errorMiddleWare(fmt.Errorf("no provider found for argument of type `%s`, ensure the type provided matches the return value of the provider", fullyQualifiedTypeString(elem)))
+			return injector.failed(elem, name, injector.errorMiddleWare(&MissingProviderError{Type: elem, Name: name}))
 		}
-		return injector.errorMiddleWare(fmt.Errorf("provider found for argument of type `%s`, but the argument was not passed by reference (i.e Resolve(&arg))", fullyQualifiedTypeString(elem)))
+		return injector.errorMiddleWare(&InvalidArgumentError{Type: elem, Reason: "a provider was found but the argument was not passed by reference (i.e Resolve(&arg))"})
 	}
 
-	instance, err := concrete.resolve(injector, name, map[reflect.Type]map[string]interface{}{})
+	instance, err := concrete.resolve(injector, name, state)
 	if err != nil {
 		return err
 	}
@@ -577,24 +929,91 @@ func (injector *Injector) resolve(abstraction interface{}, name string) error {
 // Fill takes a struct and resolves the fields with the tag `di:"inject"`
 func (injector *Injector) Fill(structure interface{}) {
 	if injector.isVerbose() {
-		injector.logDebug(fmt.Sprintf("%s%s%s", color.CyanString("Fill("), color.BlueString(debugNameString(structure)), color.CyanString(")")))
+		injector.logCall("Fill", debugNameString(structure))
 	}
 
-	err := injector.fill(structure, map[reflect.Type]map[string]interface{}{})
+	err := injector.fillContext(context.Background(), structure)
 	if err != nil {
 		injector.handleError(err)
 		return
 	}
 }
 
-func (injector *Injector) fill(structure interface{}, instantiated map[reflect.Type]map[string]interface{}) error {
+// TryFill fills like the Fill method but returns any error instead of passing it to the error handler.
+func (injector *Injector) TryFill(structure interface{}) error {
+	if injector.isVerbose() {
+		injector.logCall("TryFill", debugNameString(structure))
+	}
+
+	return injector.fillContext(context.Background(), structure)
+}
+
+// injectTag describes how a struct field is filled, parsed from its `di` tag.
+type injectTag struct {
+	byName   bool   // whether the field is filled by name rather than by type
+	name     string // the name of the binding to resolve
+	all      bool   // whether the field collects every binding of its element type
+	optional bool   // whether the field is left empty when no binding exists
+}
+
+// parseTag parses the `di` tag of a struct field.
+// It reports whether the field is tagged for injection at all, and whether the tag is valid.
+//
+// The tag holds a mode optionally followed by comma separated options:
+//
+//	di:"type"               fill by type
+//	di:"name"               fill by name, using the field name as the binding name
+//	di:"name=primary"       fill by name, using the given binding name
+//	di:"all"                fill with every binding of the element type of a slice or map
+//	di:"type,optional"      leave the field empty if no binding exists
+//	di:"-"                  never fill the field
+func parseTag(field reflect.StructField) (injectTag, bool, bool) {
+	t, exist := field.Tag.Lookup(tagName)
+	if !exist || t == injectSkip {
+		return injectTag{}, false, true
+	}
+
+	parts := strings.Split(t, ",")
+
+	var tag injectTag
+	switch mode, name, named := strings.Cut(parts[0], "="); {
+	case mode == injectByType && !named:
+	case mode == injectByName && !named:
+		tag.byName, tag.name = true, field.Name
+	case mode == injectByName && name != "":
+		tag.byName, tag.name = true, name
+	case mode == injectAll && !named:
+		tag.all = true
+	default:
+		return injectTag{}, true, false
+	}
+
+	for _, option := range parts[1:] {
+		if option != injectOptional || tag.optional {
+			return injectTag{}, true, false
+		}
+		tag.optional = true
+	}
+
+	return tag, true, true
+}
+
+// fillContext fills a struct within a new resolution call.
+func (injector *Injector) fillContext(ctx context.Context, structure interface{}) error {
+	state, cancel := injector.startResolution(ctx)
+	defer cancel()
+
+	return injector.fill(structure, state)
+}
+
+func (injector *Injector) fill(structure interface{}, state *resolution) error {
 	receiverType := reflect.TypeOf(structure)
 	if receiverType == nil {
-		return injector.errorMiddleWare(fmt.Errorf("invalid struct argument `%v`", structure))
+		return injector.errorMiddleWare(&InvalidArgumentError{Reason: "argument must be a pointer to a struct"})
 	}
 
 	if receiverType.Kind() != reflect.Ptr && receiverType.Elem().Kind() != reflect.Interface {
-		return injector.errorMiddleWare(fmt.Errorf("argument of type `%s` is not a pointer or interface", fullyQualifiedTypeString(receiverType)))
+		return injector.errorMiddleWare(&InvalidArgumentError{Type: receiverType, Reason: "argument is not a pointer or interface"})
 	}
 
 	// Allow passing structs by pointer values or pointer references i.e support both Fill(myPtr) and Fill(&myPtr)
@@ -608,9 +1027,14 @@ func (injector *Injector) fill(structure interface{}, instantiated map[reflect.T
 		}
 	}
 
+	// nil pointers and interfaces unwrap to an invalid value
+	if !value.IsValid() {
+		return injector.errorMiddleWare(&InvalidArgumentError{Type: receiverType, Reason: "argument must not be a nil pointer"})
+	}
+
 	// If the underlying type is not a struct, error
 	if value.Kind() != reflect.Struct {
-		return injector.errorMiddleWare(fmt.Errorf("argument of type `%s` is not a struct", value.Type()))
+		return injector.errorMiddleWare(&InvalidArgumentError{Type: value.Type(), Reason: "argument is not a struct"})
 	}
 
 	if injector.isVerbose() {
@@ -621,47 +1045,69 @@ func (injector *Injector) fill(structure interface{}, instantiated map[reflect.T
 	for i := 0; i < value.NumField(); i++ {
 		f := value.Field(i)
 
-		t, exist := value.Type().Field(i).Tag.Lookup(tagName)
-		if !exist {
+		tag, tagged, valid := parseTag(value.Type().Field(i))
+		if !tagged {
 			// field has no tag
 			continue
 		}
+		if !valid {
+			return injector.errorMiddleWare(&InvalidTagError{Type: value.Type(), Field: value.Type().Field(i).Name, Tag: value.Type().Field(i).Tag.Get(tagName), Path: state.chain()})
+		}
 
-		var name string
+		name := tag.name
 
-		if t == injectByType {
-			if injector.isVerbose() {
-				injector.logDebug(fmt.Sprintf("%s: field `%s %s` by type", color.MagentaString(fillingPrefix), color.BlueString(value.Type().Field(i).Name), color.GreenString(fullyQualifiedTypeString(value.Type().Field(i).Type))))
-			}
-			name = ""
-		} else if t == injectByName {
-			if injector.isVerbose() {
-				injector.logDebug(fmt.Sprintf("%s: field `%s %s` by name", color.MagentaString(fillingPrefix), color.BlueString(value.Type().Field(i).Name), color.GreenString(fullyQualifiedTypeString(value.Type().Field(i).Type))))
+		if injector.isVerbose() {
+			field := value.Type().Field(i)
+			attrs := append(typeAttrs(field.Type, name), slog.String(FieldKey, field.Name))
+			if tag.all {
+				injector.logDebug(eventFill, fmt.Sprintf("field `%s %s` with all bindings", field.Name, fullyQualifiedTypeString(field.Type)), attrs...)
+			} else if tag.byName {
+				injector.logDebug(eventFill, fmt.Sprintf("field `%s %s` by name", field.Name, fullyQualifiedTypeString(field.Type)), attrs...)
+			} else {
+				injector.logDebug(eventFill, fmt.Sprintf("field `%s %s` by type", field.Name, fullyQualifiedTypeString(field.Type)), attrs...)
 			}
-			name = value.Type().Field(i).Name
-		} else {
-			return injector.errorMiddleWare(fmt.Errorf("field `%s` has an invalid struct tag `%s`", value.Type().Field(i).Name, t))
 		}
 
-		concrete, exist := injector.bindings[f.Type()][name]
-		if !exist {
-			return injector.errorMiddleWare(fmt.Errorf("cannot resolve field `%s %s`, no provider exists for type `%s` under name: `%s` ", value.Type().Field(i).Name, fullyQualifiedTypeString(value.Type().Field(i).Type), fullyQualifiedTypeString(value.Type().Field(i).Type), value.Type().Field(i).Name))
-		}
-		instance, err := concrete.resolve(injector, name, instantiated)
-		if err != nil {
-			injector.handleError(err)
+		var resolved reflect.Value
+		if tag.all {
+			if !isMultiType(f.Type()) {
+				return injector.errorMiddleWare(&InvalidFieldError{Type: value.Type(), Field: value.Type().Field(i).Name, Reason: "is tagged `all`, must be a slice or map of a pointer or interface type", Path: state.chain()})
+			}
+
+			all, err := injector.resolveAll(f.Type(), state)
+			if err != nil {
+				return err
+			}
+			resolved = all
+		} else {
+			concrete, exist := injector.lookup(f.Type(), name)
+			if !exist && tag.optional {
+				continue
+			}
+			if !exist {
+				return injector.failed(f.Type(), name, injector.errorMiddleWare(&MissingProviderError{Type: f.Type(), Name: name, Field: value.Type().Field(i).Name, Site: state.site(), Path: state.chain()}))
+			}
+			instance, err := concrete.resolve(injector, name, state)
+			if err != nil {
+				return err
+			}
+			resolved = reflect.ValueOf(instance)
 		}
 
 		if f.CanAddr() {
 			ptr := reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
-			ptr.Set(reflect.ValueOf(instance))
+			ptr.Set(resolved)
 		} else {
 			if f.CanSet() {
-				f.Set(reflect.ValueOf(instance))
+				f.Set(resolved)
 			} else {
-				return injector.errorMiddleWare(fmt.Errorf("field `%s %s` is not an addressible or settable field, must be a pointer or inteface type", value.Type().Field(i).Name, fullyQualifiedTypeString(value.Type().Field(i).Type)))
+				return injector.errorMiddleWare(&InvalidFieldError{Type: value.Type(), Field: value.Type().Field(i).Name, Reason: "is not an addressable or settable field, must be a pointer or interface type", Path: state.chain()})
 			}
 		}
+
+		if injector.observed() {
+			injector.report(FieldFilled{Struct: value.Type(), Field: value.Type().Field(i).Name, Type: f.Type(), Name: name, Value: resolved.Interface()})
+		}
 	}
 
 	return nil
@@ -1,8 +1,10 @@
 package di
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -133,7 +135,7 @@ func TestInjector_Singleton_Fail(t *testing.T) {
 	assert.Equal(t, 3, errorCount)
 	injector.Singleton("STRING!") // must receive a function
 	assert.Equal(t, 4, errorCount)
-	injector.Singleton(func(i int) *TypeC { return &TypeC{} }) // must receive a function with no args
+	injector.Singleton(func(i int) *TypeC { return &TypeC{} }) // arguments must be pointer or interface types
 	assert.Equal(t, 5, errorCount)
 	injector.Singleton(func() *TypeC { return nil }) // must not return nil
 	injector.Call(func(t *TypeC) {})
@@ -142,6 +144,74 @@ func TestInjector_Singleton_Fail(t *testing.T) {
 	injector.Call(func(t *TypeC) {})
 	assert.Equal(t, 7, errorCount)
 }
+
+type Repo struct {
+	db  Database
+	log *John
+	C   *C `di:"type"`
+}
+
+func TestInjector_Provider_Arguments(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() Database {
+		return &MySQL{}
+	})
+	injector.Instance(func() *C {
+		return &C{Val: 7}
+	})
+	injector.Singleton(func() *John {
+		return &John{Val: 42}
+	})
+	injector.Instance(func(db Database, log *John, c *C) *Repo {
+		assert.Equal(t, 7, c.Val)
+		c.Val = 8
+		return &Repo{db: db, log: log}
+	})
+
+	repo := Get[*Repo](injector)
+	assert.NotNil(t, repo)
+	assert.IsType(t, &MySQL{}, repo.db)
+	assert.Equal(t, 42, repo.log.Val)
+	assert.Same(t, Get[*John](injector), repo.log)
+
+	// instances resolved for arguments are shared with filled fields within the same resolution call
+	assert.Equal(t, 8, repo.C.Val)
+
+	injector.Call(func(r *Repo) {
+		assert.NotNil(t, r.db)
+		assert.NotSame(t, repo, r)
+	})
+}
+
+func TestInjector_Provider_Arguments_Fail(t *testing.T) {
+	var injector = NewInjector()
+	errorCount := 0
+	injector.SetErrorHandler(func(err error) {
+		assert.Error(t, err)
+		errorCount++
+	})
+
+	// missing binding for an argument
+	injector.Singleton(func(db Database) *Repo {
+		return &Repo{db: db}
+	})
+	injector.Call(func(r *Repo) {})
+	assert.Equal(t, 1, errorCount)
+
+	// circular dependency between provider arguments
+	injector.Singleton(func(b *Bob) *Alice {
+		return &Alice{Bob: b}
+	})
+	injector.Singleton(func(a *Alice) *Bob {
+		return &Bob{Alice: a}
+	})
+	injector.Call(func(a *Alice) {})
+	assert.Equal(t, 2, errorCount)
+}
+
 func TestInjector_Singleton_Fill(t *testing.T) {
 	var injector = NewInjector()
 	injector.SetErrorHandler(func(err error) {
@@ -971,3 +1041,126 @@ func TestInjector_Reset(t *testing.T) {
 	var s Shape
 	injector.Fill(s)
 }
+
+func TestInjector_Try(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.Fail(t, "error handler should not be invoked", err.Error())
+	})
+
+	var s Shape
+	assert.Error(t, injector.TryResolve(&s))
+	assert.Error(t, injector.TryNamedResolve(&s, "C"))
+	assert.Error(t, injector.TryCall(func(s Shape) {}))
+	assert.Error(t, injector.TryFill(&struct {
+		S Shape `di:"type"`
+	}{}))
+	var p *struct {
+		S Shape `di:"type"`
+	}
+	assert.ErrorIs(t, injector.TryFill(p), ErrInvalidArgument)
+	assert.ErrorIs(t, injector.TryFill(&p), ErrInvalidArgument)
+	assert.ErrorIs(t, injector.FillContext(context.Background(), p), ErrInvalidArgument)
+
+	shape, err := TryGet[Shape](injector)
+	assert.Error(t, err)
+	assert.Nil(t, shape)
+
+	_, err = TryGet[TypeA](injector)
+	assert.Error(t, err)
+
+	injector.Singleton(func() (*TypeC, error) {
+		return nil, errors.New("provider failed")
+	})
+	_, err = TryGet[*TypeC](injector)
+	assert.ErrorContains(t, err, "provider failed")
+	assert.ErrorIs(t, err, ErrProviderFailed)
+
+	injector.Singleton(func() Shape {
+		return &Circle{a: 5}
+	})
+	injector.NamedSingleton("C", func() Shape {
+		return &Circle{a: 6}
+	})
+
+	assert.NoError(t, injector.TryResolve(&s))
+	assert.Equal(t, 5, s.GetArea())
+	assert.NoError(t, injector.TryNamedResolve(&s, "C"))
+	assert.Equal(t, 6, s.GetArea())
+	assert.NoError(t, injector.TryCall(func(s Shape) {
+		assert.Equal(t, 5, s.GetArea())
+	}))
+
+	app := struct {
+		S Shape `di:"type"`
+		C Shape `di:"name"`
+	}{}
+	assert.NoError(t, injector.TryFill(&app))
+	assert.Equal(t, 5, app.S.GetArea())
+	assert.Equal(t, 6, app.C.GetArea())
+
+	shape, err = TryNamedGet[Shape](injector, "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, shape.GetArea())
+}
+
+func TestInjector_Fill_Tag_Options(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.NamedSingleton("primary", func() Database {
+		return &MySQL{}
+	})
+	injector.NamedSingleton("C", func() Shape {
+		return &Circle{a: 5}
+	})
+
+	app := struct {
+		DB       Database `di:"name=primary"`
+		C        Shape    `di:"name"`
+		Missing  *John    `di:"type,optional"`
+		Named    *John    `di:"name=john,optional"`
+		Skipped  Shape    `di:"-"`
+		Untagged Shape
+	}{}
+	injector.Fill(&app)
+
+	assert.IsType(t, &MySQL{}, app.DB)
+	assert.Equal(t, 5, app.C.GetArea())
+	assert.Nil(t, app.Missing)
+	assert.Nil(t, app.Named)
+	assert.Nil(t, app.Skipped)
+	assert.Nil(t, app.Untagged)
+
+	// optional fields are filled when a binding exists
+	injector.NamedSingleton("john", func() *John {
+		return &John{Val: 42}
+	})
+	injector.Fill(&app)
+	assert.Nil(t, app.Missing)
+	assert.Equal(t, 42, app.Named.Val)
+
+	// optional only tolerates missing bindings, not failing providers
+	injector.Singleton(func() (*John, error) {
+		return nil, errors.New("failed")
+	})
+	assert.ErrorIs(t, injector.TryFill(&app), ErrProviderFailed)
+}
+
+func TestInjector_Fill_Tag_Options_Invalid(t *testing.T) {
+	var injector = NewInjector()
+	injector.Singleton(func() Shape {
+		return &Circle{a: 5}
+	})
+
+	for _, tag := range []string{"", "name=", "type=x", "all=x", "type,", "type,optional,optional", "type,required", "-,optional", "optional"} {
+		structType := reflect.StructOf([]reflect.StructField{{
+			Name: "S",
+			Type: reflect.TypeFor[Shape](),
+			Tag:  reflect.StructTag(`di:"` + tag + `"`),
+		}})
+		err := injector.TryFill(reflect.New(structType).Interface())
+		assert.ErrorIs(t, err, ErrInvalidTag, tag)
+	}
+}
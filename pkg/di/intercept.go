@@ -0,0 +1,134 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Interceptor runs around the method calls of an intercepted instance. It calls ctx.Proceed to invoke the next
+// interceptor, or the method itself, and returns the results of the call, which it may replace.
+type Interceptor func(ctx InvocationContext) []reflect.Value
+
+// InvocationContext describes a method call on an intercepted instance.
+type InvocationContext struct {
+	Type   reflect.Type    // the intercepted interface
+	Name   string          // the binding name, empty for unnamed bindings
+	Method reflect.Method  // the called method of the interface
+	Target interface{}     // the instance the call is forwarded to
+	Args   []reflect.Value // the arguments of the call, the last one holds the slice of a variadic method
+
+	proceed func(args []reflect.Value) []reflect.Value
+}
+
+// Proceed invokes the next interceptor, or the method of the target, with ctx.Args and returns the results.
+func (ctx InvocationContext) Proceed() []reflect.Value {
+	return ctx.proceed(ctx.Args)
+}
+
+// Invoker forwards the method calls of a proxy to the intercepted instance through its interceptors.
+type Invoker struct {
+	typ          reflect.Type
+	name         string
+	target       reflect.Value
+	interceptors []Interceptor
+}
+
+// Invoke calls the method of the intercepted instance through the interceptors, and returns its results.
+// The arguments are passed as declared by the method, the variadic arguments as a slice.
+// It panics if the interface has no such method.
+func (invoker *Invoker) Invoke(method string, args ...reflect.Value) []reflect.Value {
+	m, exist := invoker.typ.MethodByName(method)
+	if !exist {
+		panic(fmt.Sprintf("di: `%s` has no method %s", fullyQualifiedTypeString(invoker.typ), method))
+	}
+
+	fn := invoker.target.Method(m.Index)
+	call := func(args []reflect.Value) []reflect.Value {
+		if m.Type.IsVariadic() {
+			return fn.CallSlice(args)
+		}
+		return fn.Call(args)
+	}
+
+	// the first interceptor is the outermost
+	for i := len(invoker.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := invoker.interceptors[i], call
+		ctx := InvocationContext{Type: invoker.typ, Name: invoker.name, Method: m, Target: invoker.target.Interface(), proceed: next}
+		call = func(args []reflect.Value) []reflect.Value {
+			ctx := ctx
+			ctx.Args = args
+			return interceptor(ctx)
+		}
+	}
+	return call(args)
+}
+
+// proxies holds the proxy factories registered with RegisterProxy, by interface type.
+var proxies sync.Map
+
+// RegisterProxy registers the factory creating proxies of the interface. The proxy returned by the factory must
+// forward every method call to the invoker, as the proxies written by the diproxy command do:
+//
+//	func (p *userRepositoryProxy) Find(ctx context.Context, id int) (*User, error) {
+//		out := p.invoker.Invoke("Find", reflect.ValueOf(&ctx).Elem(), reflect.ValueOf(&id).Elem())
+//		user, _ := out[0].Interface().(*User)
+//		err, _ := out[1].Interface().(error)
+//		return user, err
+//	}
+//
+// Proxies are usually registered from an init function. Registering a proxy again replaces the previous one.
+func RegisterProxy[Type any](factory func(invoker *Invoker) Type) {
+	typ := reflect.TypeFor[Type]()
+	if typ.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("di: cannot register a proxy for `%s`, only interfaces can be proxied", fullyQualifiedTypeString(typ)))
+	}
+
+	proxies.Store(typ, func(invoker *Invoker) interface{} {
+		return factory(invoker)
+	})
+}
+
+// Intercept registers interceptors for the unnamed binding of the interface.
+// See InterceptNamed.
+func Intercept[Type any](i *Injector, interceptors ...Interceptor) error {
+	return InterceptNamed[Type](i, "", interceptors...)
+}
+
+// InterceptNamed registers interceptors around every method call on the instances resolved for the binding of the
+// interface registered under the name. The instances are wrapped in the proxy registered for the interface with
+// RegisterProxy, as a decorator of the binding, so interception follows the same rules as Decorate.
+// The interceptors run in order, the first one outermost, and those registered by later calls run before them.
+// It returns a *InvalidArgumentError if the type is not an interface, an interceptor is nil or no proxy is registered,
+// a *MissingProviderError if nothing is bound, or a *FrozenError if the injector is frozen.
+func InterceptNamed[Type any](i *Injector, name string, interceptors ...Interceptor) error {
+	typ := reflect.TypeFor[Type]()
+	if i.isVerbose() {
+		i.logCall("InterceptNamed", fullyQualifiedTypeString(typ), fmt.Sprintf("%q", name), fmt.Sprintf("%d interceptors", len(interceptors)))
+	}
+
+	if typ.Kind() != reflect.Interface {
+		return i.errorMiddleWare(&InvalidArgumentError{Type: typ, Reason: "only interfaces can be intercepted"})
+	}
+	if len(interceptors) == 0 {
+		return i.errorMiddleWare(&InvalidArgumentError{Type: typ, Reason: "at least one interceptor is required"})
+	}
+	for _, interceptor := range interceptors {
+		if interceptor == nil {
+			return i.errorMiddleWare(&InvalidArgumentError{Type: typ, Reason: "the interceptors must not be nil"})
+		}
+	}
+
+	factory, exist := proxies.Load(typ)
+	if !exist {
+		return i.errorMiddleWare(&InvalidArgumentError{Type: typ, Reason: "no proxy is registered for the interface, see RegisterProxy"})
+	}
+
+	interceptors = append([]Interceptor(nil), interceptors...)
+	return i.addDecorator(typ, name, func(instance interface{}) interface{} {
+		target := reflect.New(typ).Elem()
+		target.Set(reflect.ValueOf(instance))
+
+		return factory.(func(*Invoker) interface{})(&Invoker{typ: typ, name: name, target: target, interceptors: interceptors})
+	})
+}
@@ -0,0 +1,141 @@
+package di
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Calculator interface {
+	Add(a int, b int) int
+	Sum(values ...int) int
+}
+
+type SimpleCalculator struct{}
+
+func (c *SimpleCalculator) Add(a int, b int) int {
+	return a + b
+}
+
+func (c *SimpleCalculator) Sum(values ...int) int {
+	sum := 0
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
+
+// calculatorProxy is written the way the diproxy command generates proxies.
+type calculatorProxy struct {
+	invoker *Invoker
+}
+
+func (p *calculatorProxy) Add(a0 int, a1 int) int {
+	out := p.invoker.Invoke("Add", reflect.ValueOf(&a0).Elem(), reflect.ValueOf(&a1).Elem())
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (p *calculatorProxy) Sum(a0 ...int) int {
+	out := p.invoker.Invoke("Sum", reflect.ValueOf(&a0).Elem())
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func init() {
+	RegisterProxy(func(invoker *Invoker) Calculator {
+		return &calculatorProxy{invoker: invoker}
+	})
+}
+
+func TestInjector_Intercept(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() Calculator {
+		return &SimpleCalculator{}
+	})
+
+	var calls []string
+	assert.NoError(t, Intercept[Calculator](injector,
+		func(ctx InvocationContext) []reflect.Value {
+			calls = append(calls, "outer "+ctx.Method.Name)
+			assert.Equal(t, reflect.TypeFor[Calculator](), ctx.Type)
+			assert.IsType(t, &SimpleCalculator{}, ctx.Target)
+			return ctx.Proceed()
+		},
+		func(ctx InvocationContext) []reflect.Value {
+			calls = append(calls, "inner "+ctx.Method.Name)
+			if ctx.Method.Name == "Add" {
+				// interceptors can change the arguments and the results
+				ctx.Args = []reflect.Value{ctx.Args[0], reflect.ValueOf(10)}
+				out := ctx.Proceed()
+				return []reflect.Value{reflect.ValueOf(int(out[0].Int()) * 2)}
+			}
+			return ctx.Proceed()
+		},
+	))
+
+	calculator := Get[Calculator](injector)
+	assert.Same(t, calculator, Get[Calculator](injector))
+	assert.Equal(t, 22, calculator.Add(1, 2))
+	assert.Equal(t, 6, calculator.Sum(1, 2, 3))
+	assert.Equal(t, 0, calculator.Sum())
+	assert.Equal(t, []string{"outer Add", "inner Add", "outer Sum", "inner Sum", "outer Sum", "inner Sum"}, calls)
+}
+
+func TestInjector_Intercept_Named(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.NamedInstance("simple", func() Calculator {
+		return &SimpleCalculator{}
+	})
+
+	var names []string
+	record := func(label string) Interceptor {
+		return func(ctx InvocationContext) []reflect.Value {
+			names = append(names, label+" "+ctx.Name)
+			return ctx.Proceed()
+		}
+	}
+	assert.NoError(t, InterceptNamed[Calculator](injector, "simple", record("first")))
+	assert.NoError(t, InterceptNamed[Calculator](injector, "simple", record("second")))
+
+	// interceptors registered later run first
+	assert.Equal(t, 3, NamedGet[Calculator](injector, "simple").Add(1, 2))
+	assert.Equal(t, []string{"second simple", "first simple"}, names)
+}
+
+func TestInjector_Intercept_Fail(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {})
+	proceed := func(ctx InvocationContext) []reflect.Value {
+		return ctx.Proceed()
+	}
+
+	err := Intercept[*SimpleCalculator](injector, proceed)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	err = Intercept[Greeter](injector, proceed)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.ErrorContains(t, err, "no proxy is registered")
+
+	err = Intercept[Calculator](injector)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	err = Intercept[Calculator](injector, nil)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	err = Intercept[Calculator](injector, proceed)
+	assert.ErrorIs(t, err, ErrMissingProvider)
+
+	assert.Panics(t, func() {
+		RegisterProxy(func(invoker *Invoker) *SimpleCalculator {
+			return nil
+		})
+	})
+}
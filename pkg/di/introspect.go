@@ -0,0 +1,84 @@
+package di
+
+import (
+	"reflect"
+)
+
+// BindingInfo describes a binding visible to an injector.
+type BindingInfo struct {
+	Type      reflect.Type // the type the binding is registered under
+	Name      string       // the binding name, empty for unnamed bindings
+	Lifetime  string       // singleton, scoped or instance
+	Provider  reflect.Type // the type of the provider function
+	Site      string       // file:line the binding was registered from
+	Created   bool         // whether the singleton instance, or the scoped instance of the injector, has been created
+	Inherited bool         // whether the binding is registered in a parent of the scope
+}
+
+// Bindings describes every binding visible to the injector, including those inherited by a scope, ordered by type and name.
+func (injector *Injector) Bindings() []BindingInfo {
+	if injector.isVerbose() {
+		injector.logCall("Bindings")
+	}
+
+	bindings := injector.visibleBindings()
+
+	infos := []BindingInfo{}
+	for _, typ := range sortedTypes(bindings) {
+		for _, name := range sortedNames(bindings[typ]) {
+			infos = append(infos, injector.describe(typ, bindings[typ][name]))
+		}
+	}
+	return infos
+}
+
+// Describe describes the binding of a type and name visible to the injector.
+// It returns false if no such binding exists.
+func (injector *Injector) Describe(typ reflect.Type, name string) (BindingInfo, bool) {
+	b, exist := injector.lookup(typ, name)
+	if !exist {
+		return BindingInfo{}, false
+	}
+	return injector.describe(typ, b), true
+}
+
+// describe builds the description of a binding as seen from the injector.
+func (injector *Injector) describe(typ reflect.Type, b *binding) BindingInfo {
+	info := BindingInfo{
+		Type:      typ,
+		Name:      b.name,
+		Lifetime:  b.btype.String(),
+		Provider:  reflect.TypeOf(b.provider),
+		Site:      b.site,
+		Inherited: b.owner != injector,
+	}
+
+	switch b.btype {
+	case Binding_Singleton:
+		info.Created = b.created.Load()
+	case Binding_Scoped:
+		injector.scopedMu.Lock()
+		scoped, exist := injector.scoped[b]
+		injector.scopedMu.Unlock()
+
+		info.Created = exist && scoped.created.Load()
+	}
+	return info
+}
+
+// Has reports whether the injector can resolve the type.
+// Slices and maps of a type are resolvable when at least one binding of the type exists.
+func Has[Type any](i *Injector) bool {
+	return HasNamed[Type](i, "")
+}
+
+// HasNamed reports whether the injector has a binding of the type under the name.
+func HasNamed[Type any](i *Injector, name string) bool {
+	typ := reflect.TypeFor[Type]()
+	if isMultiType(typ) && name == "" {
+		return len(i.all(typ.Elem())) > 0
+	}
+
+	_, exist := i.lookup(typ, name)
+	return exist
+}
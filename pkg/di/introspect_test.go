@@ -0,0 +1,162 @@
+package di
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjector_Has(t *testing.T) {
+	var injector = NewInjector()
+	injector.Singleton(func() Database {
+		return &MySQL{}
+	})
+	injector.NamedInstance("a", func() Plugin {
+		return &NamedPlugin{name: "a"}
+	})
+
+	assert.True(t, Has[Database](injector))
+	assert.False(t, HasNamed[Database](injector, "replica"))
+	assert.False(t, Has[Plugin](injector))
+	assert.True(t, HasNamed[Plugin](injector, "a"))
+	assert.True(t, Has[[]Plugin](injector))
+	assert.True(t, Has[map[string]Plugin](injector))
+	assert.False(t, Has[[]Notifier](injector))
+
+	scope := injector.NewScope()
+	scope.Scoped(func() Notifier {
+		return &EmailNotifier{}
+	})
+	assert.True(t, Has[Database](scope))
+	assert.True(t, Has[Notifier](scope))
+	assert.False(t, Has[Notifier](injector))
+}
+
+func TestInjector_Bindings(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() Database {
+		return &MySQL{}
+	})
+	injector.NamedInstance("a", func() (Plugin, error) {
+		return &NamedPlugin{name: "a"}, nil
+	})
+	Bind[Notifier, *EmailNotifier](injector, AsScoped())
+
+	infos := injector.Bindings()
+	if assert.Len(t, infos, 3) {
+		assert.Equal(t, reflect.TypeFor[Database](), infos[0].Type)
+		assert.Equal(t, "singleton", infos[0].Lifetime)
+		assert.Equal(t, reflect.TypeFor[func() Database](), infos[0].Provider)
+		assert.Contains(t, infos[0].Site, "introspect_test.go")
+		assert.False(t, infos[0].Created)
+		assert.False(t, infos[0].Inherited)
+
+		assert.Equal(t, reflect.TypeFor[Notifier](), infos[1].Type)
+		assert.Equal(t, "scoped", infos[1].Lifetime)
+
+		assert.Equal(t, reflect.TypeFor[Plugin](), infos[2].Type)
+		assert.Equal(t, "a", infos[2].Name)
+		assert.Equal(t, "instance", infos[2].Lifetime)
+	}
+
+	Get[Database](injector)
+	NamedGet[Plugin](injector, "a")
+	info, exist := injector.Describe(reflect.TypeFor[Database](), "")
+	assert.True(t, exist)
+	assert.True(t, info.Created)
+	info, _ = injector.Describe(reflect.TypeFor[Plugin](), "a")
+	assert.False(t, info.Created)
+
+	_, exist = injector.Describe(reflect.TypeFor[Plugin](), "")
+	assert.False(t, exist)
+
+	// scoped instances are created per scope
+	scope := injector.NewScope()
+	Get[Notifier](scope)
+	info, _ = scope.Describe(reflect.TypeFor[Notifier](), "")
+	assert.True(t, info.Created)
+	assert.True(t, info.Inherited)
+	info, _ = injector.Describe(reflect.TypeFor[Notifier](), "")
+	assert.False(t, info.Created)
+	assert.False(t, info.Inherited)
+	assert.Len(t, scope.Bindings(), 3)
+}
+
+func TestInjector_Describe_Concurrent(t *testing.T) {
+	var injector = NewInjector()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var banner []BindingInfo
+	injector.Singleton(func() *John {
+		// describing from a provider does not wait for the instance being created
+		banner = injector.Bindings()
+		close(started)
+		<-release
+		return &John{Val: 42}
+	})
+
+	// an instance being created is reported as not created yet, without waiting for it
+	done := make(chan struct{})
+	go func() {
+		Get[*John](injector)
+		close(done)
+	}()
+	<-started
+	if assert.Len(t, banner, 1) {
+		assert.False(t, banner[0].Created)
+	}
+	described := make(chan BindingInfo)
+	go func() {
+		info, _ := injector.Describe(reflect.TypeFor[*John](), "")
+		described <- info
+	}()
+	select {
+	case info := <-described:
+		assert.False(t, info.Created)
+	case <-time.After(time.Second):
+		t.Fatal("Describe waited for the instance being created")
+	}
+	close(release)
+	<-done
+	info, _ := injector.Describe(reflect.TypeFor[*John](), "")
+	assert.True(t, info.Created)
+
+	// a created instance stays reported while other callers resolve it
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					Get[*John](injector)
+				}
+			}
+		}()
+	}
+	for i := 0; i < 1000; i++ {
+		info, _ := injector.Describe(reflect.TypeFor[*John](), "")
+		if !info.Created {
+			t.Error("created instance reported as not created")
+			break
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	// closed instances are reported as not created
+	assert.NoError(t, injector.Close(context.Background()))
+	info, _ = injector.Describe(reflect.TypeFor[*John](), "")
+	assert.False(t, info.Created)
+}
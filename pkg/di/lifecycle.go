@@ -0,0 +1,254 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+const (
+	eventStart = "start"
+	eventClose = "close"
+)
+
+// Sentinel errors matched by the lifecycle errors below, for use with errors.Is.
+var (
+	ErrStart = errors.New("di: start failed")
+	ErrClose = errors.New("di: close failed")
+)
+
+// OnClose registers a hook that releases a singleton instance when the injector is closed.
+// It replaces the automatic call to the instance's own Close or Stop method.
+func OnClose(hook func(ctx context.Context, instance interface{}) error) BindingOption {
+	return func(b *binding) {
+		b.onClose = hook
+	}
+}
+
+// Lazy excludes a singleton from being created by Start, it will be created on first resolution instead.
+func Lazy() BindingOption {
+	return func(b *binding) {
+		b.lazy = true
+	}
+}
+
+// StartError is returned when starting a singleton instance fails.
+type StartError struct {
+	Type reflect.Type // the type the binding is registered under
+	Name string       // the binding name, empty for unnamed bindings
+	Site string       // file:line the binding was registered from
+	Err  error        // the error returned while starting the instance
+}
+
+func (e *StartError) Error() string {
+	return fmt.Sprintf("starting instance of type `%s`%s failed: %s", fullyQualifiedTypeString(e.Type), registeredAt(e.Site), e.Err)
+}
+
+func (e *StartError) Is(target error) bool {
+	return target == ErrStart
+}
+
+func (e *StartError) Unwrap() error {
+	return e.Err
+}
+
+// CloseError is returned when releasing a singleton instance fails.
+type CloseError struct {
+	Type reflect.Type // the type the binding is registered under
+	Name string       // the binding name, empty for unnamed bindings
+	Site string       // file:line the binding was registered from
+	Err  error        // the error returned while releasing the instance
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("closing instance of type `%s`%s failed: %s", fullyQualifiedTypeString(e.Type), registeredAt(e.Site), e.Err)
+}
+
+func (e *CloseError) Is(target error) bool {
+	return target == ErrClose
+}
+
+func (e *CloseError) Unwrap() error {
+	return e.Err
+}
+
+// starter is implemented by instances that need to be started before use.
+type starter interface {
+	Start(ctx context.Context) error
+}
+
+// stopper is implemented by instances that need a context to shut down.
+type stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// closer is implemented by instances whose Close method cannot fail.
+type closer interface {
+	Close()
+}
+
+// released returns whether the instance of the binding has been released by Close.
+func (b *binding) released() bool {
+	return b.closed != nil && b.closed.Load()
+}
+
+// track records a singleton binding whose instance was just created, so it can be released on Close.
+func (injector *Injector) track(b *binding) {
+	injector.createdMu.Lock()
+	defer injector.createdMu.Unlock()
+
+	injector.created = append(injector.created, b)
+}
+
+// close releases an instance using the binding's hook, or the instance's own Close or Stop method.
+func (b *binding) close(ctx context.Context, instance interface{}) error {
+	if b.onClose != nil {
+		return b.onClose(ctx, instance)
+	}
+
+	switch i := instance.(type) {
+	case io.Closer:
+		return i.Close()
+	case closer:
+		i.Close()
+	case stopper:
+		return i.Stop(ctx)
+	}
+	return nil
+}
+
+// Start eagerly creates every singleton instance, except those registered with the Lazy option.
+// Singletons are created in dependency order, then each created instance that implements
+// `Start(ctx) error` and has not been started yet is started in the same order.
+// The first failure is returned, so misconfiguration surfaces before the application begins serving.
+func (injector *Injector) Start(ctx context.Context) error {
+	if injector.isVerbose() {
+		injector.logCall("Start")
+		injector.incrementLoggerIndent()
+		defer injector.decrementLoggerIndent()
+	}
+
+	bindings := injector.loadBindings()
+	for _, typ := range sortedTypes(bindings) {
+		for _, name := range sortedNames(bindings[typ]) {
+			b := bindings[typ][name]
+			if b.btype != Binding_Singleton || b.lazy {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			state, cancel := injector.startResolution(ctx)
+			_, err := b.resolve(injector, name, state)
+			cancel()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	injector.createdMu.Lock()
+	created := append([]*binding(nil), injector.created...)
+	injector.createdMu.Unlock()
+
+	for _, b := range created {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := injector.start(ctx, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// start calls the Start method of a singleton instance if it has one and has not been started already.
+// The binding is not locked while the instance starts, so its Start method may resolve bindings depending on it.
+func (injector *Injector) start(ctx context.Context, b *binding) error {
+	b.mu.Lock()
+	instance, started := b.instance, b.started
+	b.mu.Unlock()
+
+	s, ok := instance.(starter)
+	if !ok || !started.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	typ := reflect.TypeOf(b.provider).Out(0)
+	if injector.isVerbose() {
+		injector.logDebug(eventStart, fmt.Sprintf("singleton for type `%s`", fullyQualifiedTypeString(typ)), b.attrs()...)
+	}
+
+	if err := s.Start(ctx); err != nil {
+		// the instance can be started again by a later call
+		started.Store(false)
+		return injector.errorMiddleWare(&StartError{Type: typ, Name: b.name, Site: b.site, Err: err})
+	}
+	return nil
+}
+
+// Close releases every singleton instance created by the injector, in reverse creation order.
+// Instances are released with their OnClose hook if one was registered, otherwise with their own
+// `Close() error`, `Close()` or `Stop(ctx) error` method if they implement one.
+// The singletons are discarded, so resolving them again creates new instances.
+// Errors from each instance are aggregated and returned. If the context is done, closing stops early and
+// the remaining instances are left for a later call.
+func (injector *Injector) Close(ctx context.Context) error {
+	if injector.isVerbose() {
+		injector.logCall("Close")
+		injector.incrementLoggerIndent()
+		defer injector.decrementLoggerIndent()
+	}
+
+	injector.createdMu.Lock()
+	created := injector.created
+	injector.created = nil
+	injector.createdMu.Unlock()
+
+	var errs []error
+	for i := len(created) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			// leave the remaining instances to be released by a later call
+			injector.createdMu.Lock()
+			injector.created = append(created[:i+1:i+1], injector.created...)
+			injector.createdMu.Unlock()
+
+			errs = append(errs, err)
+			break
+		}
+
+		b := created[i]
+		b.mu.Lock()
+		instance := b.instance
+		if b.closed != nil {
+			b.closed.Store(true)
+		}
+		b.instance = nil
+		b.created.Store(false)
+		b.started = nil
+		b.mu.Unlock()
+
+		if instance == nil {
+			continue
+		}
+
+		typ := reflect.TypeOf(b.provider).Out(0)
+		if injector.isVerbose() {
+			injector.logDebug(eventClose, fmt.Sprintf("singleton for type `%s`", fullyQualifiedTypeString(typ)), b.attrs()...)
+		}
+
+		if err := b.close(ctx, instance); err != nil {
+			errs = append(errs, injector.errorMiddleWare(&CloseError{Type: typ, Name: b.name, Site: b.site, Err: err}))
+		}
+	}
+
+	err := errors.Join(errs...)
+	if injector.parent != nil && injector.observed() {
+		injector.report(ScopeClosed{Scope: injector, Err: err})
+	}
+	return err
+}
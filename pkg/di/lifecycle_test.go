@@ -0,0 +1,237 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Pool struct {
+	closed *[]string
+	name   string
+	err    error
+}
+
+func (p *Pool) Close() error {
+	*p.closed = append(*p.closed, p.name)
+	return p.err
+}
+
+type Server struct {
+	Pool   *Pool `di:"type"`
+	closed *[]string
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	*s.closed = append(*s.closed, "server")
+	return nil
+}
+
+type Cache struct {
+	closed *[]string
+}
+
+func (c *Cache) Close() {
+	*c.closed = append(*c.closed, "cache")
+}
+
+func TestInjector_Close(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+
+	var closed []string
+	injector.Singleton(func() *Pool {
+		return &Pool{closed: &closed, name: "pool"}
+	})
+	injector.Singleton(func() *Server {
+		return &Server{closed: &closed}
+	})
+	injector.Singleton(func(s *Server) *Cache {
+		return &Cache{closed: &closed}
+	})
+	injector.Singleton(func() Shape {
+		return &Circle{}
+	}, OnClose(func(ctx context.Context, instance interface{}) error {
+		closed = append(closed, "shape")
+		return nil
+	}))
+	injector.Instance(func() *John {
+		return &John{}
+	}, OnClose(func(ctx context.Context, instance interface{}) error {
+		closed = append(closed, "john")
+		return nil
+	}))
+
+	shape := Get[Shape](injector)
+	Get[*Cache](injector)
+	Get[*John](injector)
+
+	assert.NoError(t, injector.Close(context.Background()))
+	assert.Equal(t, []string{"cache", "server", "pool", "shape"}, closed)
+
+	// singletons are discarded once closed
+	assert.NotSame(t, shape, Get[Shape](injector))
+	closed = nil
+	assert.NoError(t, injector.Close(context.Background()))
+	assert.Equal(t, []string{"shape"}, closed)
+}
+
+func TestInjector_Close_Fail(t *testing.T) {
+	var injector = NewInjector()
+	var closed []string
+	errA := errors.New("a")
+	errB := errors.New("b")
+	injector.NamedSingleton("a", func() *Pool {
+		return &Pool{closed: &closed, name: "a", err: errA}
+	})
+	injector.NamedSingleton("b", func() *Pool {
+		return &Pool{closed: &closed, name: "b", err: errB}
+	})
+	injector.NamedSingleton("c", func() *Pool {
+		return &Pool{closed: &closed, name: "c"}
+	})
+	NamedGet[*Pool](injector, "a")
+	NamedGet[*Pool](injector, "b")
+	NamedGet[*Pool](injector, "c")
+
+	err := injector.Close(context.Background())
+	assert.Equal(t, []string{"c", "b", "a"}, closed)
+	assert.ErrorIs(t, err, ErrClose)
+	assert.ErrorIs(t, err, errA)
+	assert.ErrorIs(t, err, errB)
+
+	var closeErr *CloseError
+	if assert.ErrorAs(t, err, &closeErr) {
+		assert.Equal(t, "b", closeErr.Name)
+	}
+
+	// a cancelled context leaves the instances for a later call
+	closed = nil
+	NamedGet[*Pool](injector, "c")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, injector.Close(ctx), context.Canceled)
+	assert.Empty(t, closed)
+	assert.NoError(t, injector.Close(context.Background()))
+	assert.Equal(t, []string{"c"}, closed)
+}
+
+type Engine struct {
+	started *[]string
+	name    string
+	err     error
+}
+
+func (e *Engine) Start(ctx context.Context) error {
+	*e.started = append(*e.started, e.name)
+	return e.err
+}
+
+type Car struct {
+	Engine *Engine `di:"type"`
+}
+
+type Listener struct {
+	injector *Injector
+	router   *Router
+}
+
+// Start resolves a binding depending on the listener itself.
+func (l *Listener) Start(ctx context.Context) error {
+	router, err := GetContext[*Router](ctx, l.injector)
+	l.router = router
+	return err
+}
+
+type Router struct {
+	Listener *Listener
+}
+
+func TestInjector_Start(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+
+	var started []string
+	created := map[string]bool{}
+	injector.Singleton(func() *Engine {
+		created["engine"] = true
+		return &Engine{started: &started, name: "engine"}
+	})
+	injector.NamedSingleton("spare", func() *Engine {
+		created["spare"] = true
+		return &Engine{started: &started, name: "spare"}
+	}, Lazy())
+	injector.Singleton(func() *Car {
+		created["car"] = true
+		return &Car{}
+	})
+	injector.Instance(func() *John {
+		created["john"] = true
+		return &John{}
+	})
+
+	assert.NoError(t, injector.Start(context.Background()))
+	assert.Equal(t, map[string]bool{"engine": true, "car": true}, created)
+	assert.Equal(t, []string{"engine"}, started)
+
+	// already started instances are not started twice, lazily created ones are picked up
+	NamedGet[*Engine](injector, "spare")
+	assert.NoError(t, injector.Start(context.Background()))
+	assert.Equal(t, []string{"engine", "spare"}, started)
+}
+
+func TestInjector_Start_Resolve_Dependent(t *testing.T) {
+	var injector = NewInjector()
+	injector.Singleton(func() *Listener {
+		return &Listener{injector: injector}
+	})
+	injector.Singleton(func(listener *Listener) *Router {
+		return &Router{Listener: listener}
+	}, Lazy())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- injector.Start(context.Background())
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Start blocked on the binding of the instance being started")
+	}
+
+	listener := Get[*Listener](injector)
+	if assert.NotNil(t, listener.router) {
+		assert.Same(t, listener, listener.router.Listener)
+	}
+}
+
+func TestInjector_Start_Fail(t *testing.T) {
+	var injector = NewInjector()
+	var started []string
+	errEngine := errors.New("engine")
+	injector.Singleton(func() *Engine {
+		return &Engine{started: &started, name: "engine", err: errEngine}
+	})
+
+	err := injector.Start(context.Background())
+	assert.ErrorIs(t, err, ErrStart)
+	assert.ErrorIs(t, err, errEngine)
+
+	injector.Singleton(func() (*Car, error) {
+		return nil, errors.New("car")
+	})
+	err = injector.Start(context.Background())
+	assert.ErrorIs(t, err, ErrProviderFailed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, injector.Start(ctx), context.Canceled)
+}
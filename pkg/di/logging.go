@@ -0,0 +1,239 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// Attribute keys of the records logged by the injector.
+const (
+	EventKey    = "event"    // the kind of activity, such as bind, resolve, invoke, fill, return or error
+	TypeKey     = "type"     // the type being bound or resolved
+	NameKey     = "name"     // the binding name, omitted for unnamed bindings
+	LifetimeKey = "lifetime" // singleton, scoped or instance
+	DepthKey    = "depth"    // the nesting depth of the activity within the current call
+	DurationKey = "duration" // the time the provider took
+	SiteKey     = "site"     // file:line the binding was registered from
+	FieldKey    = "field"    // the name of the field being filled
+	MethodKey   = "method"   // the method of the injector being called
+	ErrorKey    = "error"    // the error being returned
+)
+
+const (
+	eventCall    = "call"
+	eventError   = "error"
+	eventBind    = "bind"
+	eventResolve = "resolve"
+	eventReturn  = "return"
+	eventInvoke  = "invoke"
+	eventFill    = "fill"
+)
+
+// eventLabels are the labels the pretty handler shows for the events.
+var eventLabels = map[string]string{
+	eventBind:     "BINDING",
+	eventResolve:  "RESOLVING",
+	eventReturn:   "RETURNING",
+	eventInvoke:   "INVOKING",
+	eventFill:     "FILLING",
+	eventDecorate: "DECORATING",
+	eventOverride: "OVERRIDING",
+	eventUnbind:   "UNBINDING",
+	eventReplace:  "REPLACING",
+	eventStart:    "STARTING",
+	eventClose:    "CLOSING",
+}
+
+// SetSlogLogger sends the records of the injector to the structured logger instead of the logger set with SetLogger
+// or the standard output. Each record holds the EventKey, TypeKey, NameKey, LifetimeKey, DepthKey, DurationKey and
+// SiteKey attributes that apply to it. Debug records are logged while debug logging is enabled, warnings always.
+// A nil logger restores the default output. Scopes created afterwards inherit the logger.
+func (injector *Injector) SetSlogLogger(logger *slog.Logger) {
+	injector.mu.Lock()
+	defer injector.mu.Unlock()
+
+	injector.slogger = logger
+}
+
+// logDebug logs a debug record of the injector activity.
+func (injector *Injector) logDebug(event string, msg string, attrs ...slog.Attr) {
+	injector.log(slog.LevelDebug, event, msg, attrs)
+}
+
+// logWarning logs a warning whether or not debug logging is enabled.
+func (injector *Injector) logWarning(event string, msg string, attrs ...slog.Attr) {
+	injector.log(slog.LevelWarn, event, msg, attrs)
+}
+
+// logCall logs a call to a method of the injector, with its arguments.
+func (injector *Injector) logCall(method string, args ...string) {
+	injector.log(slog.LevelDebug, eventCall, fmt.Sprintf("%s(%s)", method, strings.Join(args, ", ")), []slog.Attr{slog.String(MethodKey, method)})
+}
+
+// log sends a record to the structured logger if one is set, or else renders it as plain text with the pretty
+// handler to the logger set with SetLogger or the standard output.
+func (injector *Injector) log(level slog.Level, event string, msg string, attrs []slog.Attr) {
+	injector.mu.RLock()
+	slogger, logger := injector.slogger, injector.logger
+	injector.mu.RUnlock()
+
+	attrs = append([]slog.Attr{slog.String(EventKey, event), slog.Int(DepthKey, int(atomic.LoadInt32(&injector.verboseIndent)))}, attrs...)
+
+	if slogger != nil {
+		slogger.LogAttrs(context.Background(), level, msg, attrs...)
+		return
+	}
+
+	write := func(line string) {
+		fmt.Println(line)
+	}
+	if logger != nil {
+		write = func(line string) {
+			logger.Print(line)
+		}
+	}
+
+	record := slog.NewRecord(time.Now(), level, msg, 0)
+	record.AddAttrs(attrs...)
+	(&prettyHandler{level: slog.LevelDebug, print: write}).Handle(context.Background(), record)
+}
+
+// typeAttrs returns the attributes of a type and a binding name.
+func typeAttrs(typ reflect.Type, name string) []slog.Attr {
+	attrs := []slog.Attr{slog.String(TypeKey, fullyQualifiedTypeString(typ))}
+	if name != "" {
+		attrs = append(attrs, slog.String(NameKey, name))
+	}
+	return attrs
+}
+
+// attrs returns the attributes of the binding.
+func (b *binding) attrs() []slog.Attr {
+	attrs := append(typeAttrs(reflect.TypeOf(b.provider).Out(0), b.name), slog.String(LifetimeKey, b.btype.String()))
+	if b.site != "" {
+		attrs = append(attrs, slog.String(SiteKey, b.site))
+	}
+	return attrs
+}
+
+// prettyHandler renders records as the indented lines of the injector debug output, colored if enabled.
+type prettyHandler struct {
+	level   slog.Leveler
+	colored bool
+	print   func(line string)
+}
+
+// NewPrettyHandler creates a handler writing records to w as plain text lines, indented by their DepthKey attribute:
+//
+//	di: ╰-> RESOLVING: provider for type `*main.Service` registered at main.go:42
+//
+// The level defaults to slog.LevelDebug. Only the message, event and depth of the records are shown, the other
+// attributes being part of the messages of the injector. This is the rendering used when no slog logger is set.
+func NewPrettyHandler(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	return newPrettyHandler(w, opts, false)
+}
+
+// NewColorHandler creates a handler writing records to w like NewPrettyHandler, with the labels and quoted types
+// colored. Colors are still disabled when w is not a terminal, see color.NoColor.
+func NewColorHandler(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	return newPrettyHandler(w, opts, true)
+}
+
+func newPrettyHandler(w io.Writer, opts *slog.HandlerOptions, colored bool) slog.Handler {
+	var level slog.Leveler = slog.LevelDebug
+	if opts != nil && opts.Level != nil {
+		level = opts.Level
+	}
+
+	var mu sync.Mutex
+	return &prettyHandler{level: level, colored: colored, print: func(line string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		fmt.Fprintln(w, line)
+	}}
+}
+
+func (h *prettyHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *prettyHandler) Handle(_ context.Context, record slog.Record) error {
+	event, depth := "", 0
+	record.Attrs(func(attr slog.Attr) bool {
+		switch attr.Key {
+		case EventKey:
+			event = attr.Value.String()
+		case DepthKey:
+			depth = int(attr.Value.Int64())
+		}
+		return true
+	})
+
+	prefix := "di: "
+	for i := 0; i < depth; i++ {
+		if i == depth-1 {
+			prefix += "╰-> "
+		} else {
+			prefix += "    "
+		}
+	}
+
+	var line string
+	switch {
+	case record.Level >= slog.LevelError:
+		line = fmt.Sprintf("%s: %s", h.paint(color.RedString, "ERROR"), record.Message)
+	case record.Level >= slog.LevelWarn:
+		line = fmt.Sprintf("%s: %s", h.paint(color.YellowString, "WARNING"), h.highlight(record.Message))
+	case event == eventCall:
+		line = h.paint(color.CyanString, record.Message)
+	case eventLabels[event] != "":
+		line = fmt.Sprintf("%s: %s", h.paint(color.MagentaString, eventLabels[event]), h.highlight(record.Message))
+	default:
+		line = h.highlight(record.Message)
+	}
+
+	h.print(prefix + line)
+	return nil
+}
+
+// WithAttrs returns the handler itself, as only the message, event and depth of the records are shown.
+func (h *prettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h
+}
+
+// WithGroup returns the handler itself, as only the message, event and depth of the records are shown.
+func (h *prettyHandler) WithGroup(name string) slog.Handler {
+	return h
+}
+
+// quoted matches the types and fields quoted in the messages of the injector.
+var quoted = regexp.MustCompile("`[^`]*`")
+
+// paint colors the text with the color function if the handler is colored.
+func (h *prettyHandler) paint(colorString func(format string, a ...interface{}) string, text string) string {
+	if !h.colored {
+		return text
+	}
+	return colorString("%s", text)
+}
+
+// highlight colors the quoted parts of a message if the handler is colored.
+func (h *prettyHandler) highlight(msg string) string {
+	if !h.colored {
+		return msg
+	}
+	return quoted.ReplaceAllStringFunc(msg, func(s string) string {
+		return color.BlueString("%s", s)
+	})
+}
@@ -0,0 +1,193 @@
+package di
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+type Audited struct {
+	John *John `di:"type"`
+}
+
+// records decodes the records written by a JSON handler.
+func records(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var all []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		all = append(all, record)
+	}
+	return all
+}
+
+// find returns the first record of the event whose message starts with the prefix, or nil.
+func find(all []map[string]interface{}, event string, prefix string) map[string]interface{} {
+	for _, record := range all {
+		if record[EventKey] == event && strings.HasPrefix(record[slog.MessageKey].(string), prefix) {
+			return record
+		}
+	}
+	return nil
+}
+
+func TestInjector_SetSlogLogger(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {})
+	var buf bytes.Buffer
+	injector.SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	injector.EnableDebugLogging()
+
+	injector.NamedSingleton("john", func() *John {
+		return &John{Val: 42}
+	})
+	injector.Singleton(func() *John {
+		return &John{Val: 42}
+	})
+	injector.Fill(&Audited{})
+
+	all := records(t, &buf)
+
+	call := find(all, eventCall, "")
+	if assert.NotNil(t, call) {
+		assert.Equal(t, "DEBUG", call[slog.LevelKey])
+		assert.Equal(t, "NamedSingleton", call[MethodKey])
+	}
+
+	bind := find(all, eventBind, "")
+	if assert.NotNil(t, bind) {
+		assert.Equal(t, "*di.John", bind[TypeKey])
+		assert.Equal(t, "john", bind[NameKey])
+		assert.Equal(t, "singleton", bind[LifetimeKey])
+		assert.Contains(t, bind[SiteKey], "logging_test.go:")
+	}
+
+	fill := find(all, eventFill, "")
+	if assert.NotNil(t, fill) {
+		assert.Equal(t, "*di.John", fill[TypeKey])
+		assert.Equal(t, "John", fill[FieldKey])
+		assert.NotContains(t, fill, NameKey)
+	}
+
+	returned := find(all, eventReturn, "value")
+	if assert.NotNil(t, returned) {
+		assert.Contains(t, returned, DurationKey)
+	}
+
+	// nested activities are deeper than the call they belong to
+	resolve := find(all, eventResolve, "")
+	if assert.NotNil(t, resolve) {
+		assert.Greater(t, resolve[DepthKey], float64(0))
+		assert.Equal(t, "singleton", resolve[LifetimeKey])
+	}
+
+	// errors are logged at the error level with the error
+	buf.Reset()
+	TryGet[*Alice](injector)
+	failed := find(records(t, &buf), eventError, "")
+	if assert.NotNil(t, failed) {
+		assert.Equal(t, "ERROR", failed[slog.LevelKey])
+		assert.Contains(t, failed[ErrorKey], "*di.Alice")
+	}
+
+	// nothing but warnings is logged once debug logging is disabled
+	buf.Reset()
+	injector.DisableDebugLogging()
+	Get[*John](injector)
+	assert.Empty(t, buf.String())
+
+	// scopes inherit the logger
+	scope := injector.NewScope()
+	scope.EnableDebugLogging()
+	Get[*John](scope)
+	assert.NotEmpty(t, buf.String())
+
+	// removing the logger restores the default output
+	injector.SetSlogLogger(nil)
+	buf.Reset()
+	injector.EnableDebugLogging()
+	Get[*John](injector)
+	assert.Empty(t, buf.String())
+}
+
+func TestInjector_SetSlogLogger_Warning(t *testing.T) {
+	var injector = NewInjector()
+	var buf bytes.Buffer
+	injector.SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	injector.Instance(func() *John {
+		time.Sleep(20 * time.Millisecond)
+		return &John{}
+	})
+
+	injector.SetSlowProviderThreshold(10 * time.Millisecond)
+	Get[*John](injector)
+
+	warning := find(records(t, &buf), eventInvoke, "")
+	if assert.NotNil(t, warning) {
+		assert.Equal(t, "WARN", warning[slog.LevelKey])
+		assert.Equal(t, "*di.John", warning[TypeKey])
+		assert.GreaterOrEqual(t, warning[DurationKey], float64(20*time.Millisecond))
+		assert.Contains(t, warning[SiteKey], "logging_test.go:")
+	}
+}
+
+func TestNewPrettyHandler(t *testing.T) {
+	defer func(noColor bool) {
+		color.NoColor = noColor
+	}(color.NoColor)
+	color.NoColor = false
+
+	var injector = NewInjector()
+	var buf bytes.Buffer
+	injector.SetSlogLogger(slog.New(NewPrettyHandler(&buf, nil)))
+	injector.EnableDebugLogging()
+
+	injector.Singleton(func() *John {
+		return &John{Val: 42}
+	})
+	Get[*John](injector)
+
+	assert.Contains(t, buf.String(), "di: Singleton(func() *di.John)\n")
+	assert.Contains(t, buf.String(), "di: ╰-> BINDING: singleton provider for type `*di.John`")
+	assert.Contains(t, buf.String(), "di: ╰-> RESOLVING: provider for type `*di.John` registered at ")
+	assert.Contains(t, buf.String(), "di: ╰-> RETURNING: value &{Val:42}\n")
+
+	// the level of the handler filters the records
+	buf.Reset()
+	injector.SetSlogLogger(slog.New(NewPrettyHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	TryGet[*Alice](injector)
+	assert.Equal(t, "di: ╰-> ERROR: no provider found for type `*di.Alice`, ensure the type provided matches the return value of the provider\n", buf.String())
+}
+
+func TestNewColorHandler(t *testing.T) {
+	defer func(noColor bool) {
+		color.NoColor = noColor
+	}(color.NoColor)
+	color.NoColor = false
+
+	var injector = NewInjector()
+	var buf bytes.Buffer
+	injector.SetSlogLogger(slog.New(NewColorHandler(&buf, nil)))
+	injector.EnableDebugLogging()
+
+	injector.Singleton(func() *John {
+		return &John{Val: 42}
+	})
+	assert.Contains(t, buf.String(), color.MagentaString("BINDING")+": singleton provider for type "+color.BlueString("`*di.John`"))
+
+	// colors are disabled when the output is not a terminal
+	buf.Reset()
+	color.NoColor = true
+	Get[*John](injector)
+	assert.Contains(t, buf.String(), "di: ╰-> RESOLVING: provider for type `*di.John` registered at ")
+}
@@ -0,0 +1,229 @@
+package di
+
+import (
+	"expvar"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MetricsSink receives the measurements taken by an injector while resolving its bindings.
+// Sinks are called concurrently and must be safe for concurrent use.
+type MetricsSink interface {
+	// Resolved is called for each resolution of a binding, with the error if it failed, including because of a dependency.
+	Resolved(typ reflect.Type, name string, err error)
+	// ProviderCalled is called each time the provider of a binding returns, with the time it took.
+	ProviderCalled(typ reflect.Type, name string, elapsed time.Duration)
+	// LockWaited is called when a resolution waited for another caller creating the same singleton or scoped instance.
+	LockWaited(typ reflect.Type, name string, elapsed time.Duration)
+}
+
+// BindingStats holds the measurements of a binding.
+type BindingStats struct {
+	Type          reflect.Type  // the type the binding is registered under
+	Name          string        // the binding name, empty for unnamed bindings
+	Resolves      uint64        // the number of resolutions of the binding
+	Failures      uint64        // the number of resolutions that failed
+	LockWaits     uint64        // the number of resolutions that waited for another caller creating the instance
+	LockWaitTotal time.Duration // the total time spent waiting
+	ProviderCalls uint64        // the number of times the provider was invoked
+	ProviderMin   time.Duration // the shortest time the provider took
+	ProviderAvg   time.Duration // the average time the provider took
+	ProviderMax   time.Duration // the longest time the provider took
+	ProviderTotal time.Duration // the total time spent in the provider
+}
+
+// bindingKey identifies a binding in the measurements.
+type bindingKey struct {
+	typ  reflect.Type
+	name string
+}
+
+// MemorySink is a MetricsSink keeping the measurements in memory. It is the sink behind Injector.Stats.
+type MemorySink struct {
+	mu    sync.Mutex
+	stats map[bindingKey]*BindingStats
+}
+
+// NewMemorySink creates an empty MemorySink.
+func NewMemorySink() *MemorySink {
+	return &MemorySink{stats: map[bindingKey]*BindingStats{}}
+}
+
+// get returns the measurements of a binding, it must be called with the lock held.
+func (s *MemorySink) get(typ reflect.Type, name string) *BindingStats {
+	key := bindingKey{typ: typ, name: name}
+	stats, exist := s.stats[key]
+	if !exist {
+		stats = &BindingStats{Type: typ, Name: name}
+		s.stats[key] = stats
+	}
+	return stats
+}
+
+func (s *MemorySink) Resolved(typ reflect.Type, name string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.get(typ, name)
+	stats.Resolves++
+	if err != nil {
+		stats.Failures++
+	}
+}
+
+func (s *MemorySink) ProviderCalled(typ reflect.Type, name string, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.get(typ, name)
+	if stats.ProviderCalls == 0 || elapsed < stats.ProviderMin {
+		stats.ProviderMin = elapsed
+	}
+	if elapsed > stats.ProviderMax {
+		stats.ProviderMax = elapsed
+	}
+	stats.ProviderCalls++
+	stats.ProviderTotal += elapsed
+	stats.ProviderAvg = stats.ProviderTotal / time.Duration(stats.ProviderCalls)
+}
+
+func (s *MemorySink) LockWaited(typ reflect.Type, name string, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.get(typ, name)
+	stats.LockWaits++
+	stats.LockWaitTotal += elapsed
+}
+
+// Stats returns a copy of the measurements of every binding measured so far, ordered by type and name.
+func (s *MemorySink) Stats() []BindingStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := make([]BindingStats, 0, len(s.stats))
+	for _, stats := range s.stats {
+		all = append(all, *stats)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Type != all[j].Type {
+			return fullyQualifiedTypeString(all[i].Type) < fullyQualifiedTypeString(all[j].Type)
+		}
+		return all[i].Name < all[j].Name
+	})
+	return all
+}
+
+// Reset discards every measurement.
+func (s *MemorySink) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats = map[bindingKey]*BindingStats{}
+}
+
+// ExpvarSink is a MetricsSink publishing the measurements as expvar variables, one map per binding keyed
+// by `type` or `type[name]`, holding resolves, failures, lock_waits, lock_wait_ns, provider_calls, provider_ns,
+// provider_min_ns and provider_max_ns.
+type ExpvarSink struct {
+	mu   sync.Mutex
+	vars *expvar.Map
+}
+
+// NewExpvarSink creates an ExpvarSink publishing its variables under the name.
+// Sinks created with the same name share their variables.
+func NewExpvarSink(name string) *ExpvarSink {
+	vars, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		vars = expvar.NewMap(name)
+	}
+	return &ExpvarSink{vars: vars}
+}
+
+// get returns the variables of a binding, it must be called with the lock held.
+func (s *ExpvarSink) get(typ reflect.Type, name string) *expvar.Map {
+	key := nodeID(typ, name)
+	vars, ok := s.vars.Get(key).(*expvar.Map)
+	if !ok {
+		vars = new(expvar.Map).Init()
+		s.vars.Set(key, vars)
+	}
+	return vars
+}
+
+func (s *ExpvarSink) Resolved(typ reflect.Type, name string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vars := s.get(typ, name)
+	vars.Add("resolves", 1)
+	if err != nil {
+		vars.Add("failures", 1)
+	}
+}
+
+func (s *ExpvarSink) ProviderCalled(typ reflect.Type, name string, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vars := s.get(typ, name)
+	if shortest, exist := vars.Get("provider_min_ns").(*expvar.Int); !exist || int64(elapsed) < shortest.Value() {
+		vars.Set("provider_min_ns", intVar(int64(elapsed)))
+	}
+	if longest, exist := vars.Get("provider_max_ns").(*expvar.Int); !exist || int64(elapsed) > longest.Value() {
+		vars.Set("provider_max_ns", intVar(int64(elapsed)))
+	}
+	vars.Add("provider_calls", 1)
+	vars.Add("provider_ns", int64(elapsed))
+}
+
+func (s *ExpvarSink) LockWaited(typ reflect.Type, name string, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vars := s.get(typ, name)
+	vars.Add("lock_waits", 1)
+	vars.Add("lock_wait_ns", int64(elapsed))
+}
+
+// intVar creates an unpublished expvar.Int holding the value.
+func intVar(value int64) *expvar.Int {
+	v := new(expvar.Int)
+	v.Set(value)
+	return v
+}
+
+// SetMetricsSink sets a sink receiving the measurements of the injector, in addition to the in-memory
+// measurements returned by Stats. A nil sink removes it. Scopes created afterwards report to the same sink.
+func (injector *Injector) SetMetricsSink(sink MetricsSink) {
+	injector.mu.Lock()
+	defer injector.mu.Unlock()
+
+	injector.sink = sink
+}
+
+// Stats returns the measurements of every binding resolved through the injector, ordered by type and name.
+// Scopes record their measurements into the stats of the injector they are created from.
+func (injector *Injector) Stats() []BindingStats {
+	if injector.isVerbose() {
+		injector.logCall("Stats")
+	}
+
+	return injector.metrics.Stats()
+}
+
+// sinks calls the function with the in-memory sink and the sink set with SetMetricsSink.
+func (injector *Injector) sinks(measure func(sink MetricsSink)) {
+	measure(injector.metrics)
+
+	injector.mu.RLock()
+	sink := injector.sink
+	injector.mu.RUnlock()
+
+	if sink != nil {
+		measure(sink)
+	}
+}
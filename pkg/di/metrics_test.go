@@ -0,0 +1,156 @@
+package di
+
+import (
+	"errors"
+	"expvar"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingSink records the bindings it is told about.
+type recordingSink struct {
+	mu       sync.Mutex
+	resolved []string
+	failed   []string
+}
+
+func (s *recordingSink) Resolved(typ reflect.Type, name string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resolved = append(s.resolved, nodeID(typ, name))
+	if err != nil {
+		s.failed = append(s.failed, nodeID(typ, name))
+	}
+}
+
+func (s *recordingSink) ProviderCalled(typ reflect.Type, name string, elapsed time.Duration) {}
+
+func (s *recordingSink) LockWaited(typ reflect.Type, name string, elapsed time.Duration) {}
+
+func statsOf(t *testing.T, injector *Injector, typ reflect.Type, name string) BindingStats {
+	for _, stats := range injector.Stats() {
+		if stats.Type == typ && stats.Name == name {
+			return stats
+		}
+	}
+	t.Fatalf("no stats for %s", nodeID(typ, name))
+	return BindingStats{}
+}
+
+func TestInjector_Stats(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {})
+	injector.Singleton(func() *John {
+		time.Sleep(time.Millisecond)
+		return &John{}
+	})
+	injector.NamedInstance("fast", func() *John {
+		return &John{}
+	})
+	injector.Singleton(func(database Database) *Repo {
+		return &Repo{}
+	})
+	injector.Singleton(func() (Database, error) {
+		return nil, errors.New("no database")
+	})
+
+	for i := 0; i < 3; i++ {
+		Get[*John](injector)
+		NamedGet[*John](injector, "fast")
+	}
+	Get[*John](injector.NewScope())
+	Get[*Repo](injector)
+
+	john := statsOf(t, injector, reflect.TypeFor[*John](), "")
+	assert.Equal(t, uint64(4), john.Resolves)
+	assert.Equal(t, uint64(1), john.ProviderCalls)
+	assert.GreaterOrEqual(t, john.ProviderMin, time.Millisecond)
+	assert.Equal(t, john.ProviderMin, john.ProviderAvg)
+	assert.Equal(t, john.ProviderMin, john.ProviderMax)
+	assert.Equal(t, john.ProviderMin, john.ProviderTotal)
+
+	fast := statsOf(t, injector, reflect.TypeFor[*John](), "fast")
+	assert.Equal(t, uint64(3), fast.ProviderCalls)
+	assert.LessOrEqual(t, fast.ProviderMin, fast.ProviderAvg)
+	assert.LessOrEqual(t, fast.ProviderAvg, fast.ProviderMax)
+
+	// failures are counted for the failing binding and the bindings depending on it
+	assert.Equal(t, uint64(1), statsOf(t, injector, reflect.TypeFor[Database](), "").Failures)
+	assert.Equal(t, uint64(1), statsOf(t, injector, reflect.TypeFor[*Repo](), "").Failures)
+	assert.Equal(t, uint64(0), john.Failures)
+}
+
+func TestInjector_Stats_LockWaits(t *testing.T) {
+	var injector = NewInjector()
+	started, release := make(chan struct{}), make(chan struct{})
+	injector.Singleton(func() *John {
+		close(started)
+		<-release
+		return &John{}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		Get[*John](injector)
+		close(done)
+	}()
+	<-started
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+	Get[*John](injector)
+	<-done
+
+	john := statsOf(t, injector, reflect.TypeFor[*John](), "")
+	assert.Equal(t, uint64(1), john.LockWaits)
+	assert.Greater(t, john.LockWaitTotal, time.Duration(0))
+}
+
+func TestInjector_SetMetricsSink(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {})
+	injector.Singleton(func() *John {
+		return &John{}
+	})
+
+	sink := &recordingSink{}
+	injector.SetMetricsSink(sink)
+	expvarSink := NewExpvarSink("di_test_metrics")
+	assert.Same(t, expvarSink.vars, NewExpvarSink("di_test_metrics").vars)
+
+	Get[*John](injector)
+	Get[*Bob](injector.NewScope())
+	assert.Equal(t, []string{"*di.John"}, sink.resolved)
+	assert.Empty(t, sink.failed)
+
+	// expvar variables are global, so only their changes are checked
+	resolves := func() int64 {
+		vars, ok := expvar.Get("di_test_metrics").(*expvar.Map).Get("*di.John").(*expvar.Map)
+		if !ok {
+			return 0
+		}
+		return vars.Get("resolves").(*expvar.Int).Value()
+	}
+	before := resolves()
+	injector.SetMetricsSink(expvarSink)
+	Get[*John](injector)
+	Get[*John](injector)
+	assert.Equal(t, before+2, resolves())
+
+	expvarSink.ProviderCalled(reflect.TypeFor[*Bob](), "slow", 5*time.Millisecond)
+	expvarSink.ProviderCalled(reflect.TypeFor[*Bob](), "slow", 3*time.Millisecond)
+	bob := expvar.Get("di_test_metrics").(*expvar.Map).Get("*di.Bob[slow]").(*expvar.Map)
+	assert.Equal(t, int64(3*time.Millisecond), bob.Get("provider_min_ns").(*expvar.Int).Value())
+	assert.Equal(t, int64(5*time.Millisecond), bob.Get("provider_max_ns").(*expvar.Int).Value())
+
+	// the in-memory stats are kept whatever the sink
+	injector.SetMetricsSink(nil)
+	assert.Equal(t, uint64(3), statsOf(t, injector, reflect.TypeFor[*John](), "").Resolves)
+}
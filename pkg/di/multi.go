@@ -0,0 +1,78 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// isMultiType reports whether a type collects every binding of its element type,
+// that is a slice, or a map keyed by binding name, of a pointer or interface type.
+func isMultiType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Slice:
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return false
+		}
+	default:
+		return false
+	}
+	return typ.Elem().Kind() == reflect.Ptr || typ.Elem().Kind() == reflect.Interface
+}
+
+// all returns every binding of a type in registration order, including those inherited by a scope.
+// Bindings registered in a scope override the inherited bindings of the same name.
+func (injector *Injector) all(typ reflect.Type) []*binding {
+	seen := map[string]bool{}
+	var all []*binding
+	for i := injector; i != nil; i = i.parent {
+		for name, b := range i.loadBindings()[typ] {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			all = append(all, b)
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].seq < all[j].seq
+	})
+	return all
+}
+
+// resolveAll resolves every binding of the element type of a slice or map type.
+// Slices hold the instances in registration order, maps hold them keyed by binding name.
+func (injector *Injector) resolveAll(typ reflect.Type, state *resolution) (reflect.Value, error) {
+	if injector.isVerbose() {
+		injector.incrementLoggerIndent()
+		defer injector.decrementLoggerIndent()
+
+		injector.logDebug(eventResolve, fmt.Sprintf("all providers for type `%s`", fullyQualifiedTypeString(typ.Elem())), typeAttrs(typ.Elem(), "")...)
+	}
+
+	bindings := injector.all(typ.Elem())
+
+	var result reflect.Value
+	if typ.Kind() == reflect.Slice {
+		result = reflect.MakeSlice(typ, 0, len(bindings))
+	} else {
+		result = reflect.MakeMapWithSize(typ, len(bindings))
+	}
+
+	for _, b := range bindings {
+		instance, err := b.resolve(injector, b.name, state)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		if typ.Kind() == reflect.Slice {
+			result = reflect.Append(result, reflect.ValueOf(instance))
+		} else {
+			result.SetMapIndex(reflect.ValueOf(b.name).Convert(typ.Key()), reflect.ValueOf(instance))
+		}
+	}
+
+	return result, nil
+}
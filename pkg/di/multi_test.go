@@ -0,0 +1,123 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Plugin interface {
+	Name() string
+}
+
+type NamedPlugin struct {
+	name string
+}
+
+func (p *NamedPlugin) Name() string {
+	return p.name
+}
+
+type PluginHost struct {
+	List    []Plugin          `di:"all"`
+	ByName  map[string]Plugin `di:"all"`
+	Default Plugin            `di:"type"`
+}
+
+func TestInjector_Multibindings(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.NamedSingleton("c", func() Plugin {
+		return &NamedPlugin{name: "c"}
+	})
+	injector.Singleton(func() Plugin {
+		return &NamedPlugin{name: "default"}
+	})
+	injector.NamedInstance("a", func() Plugin {
+		return &NamedPlugin{name: "a"}
+	})
+	injector.NamedSingleton("b", func() Plugin {
+		return &NamedPlugin{name: "b"}
+	})
+
+	names := func(plugins []Plugin) []string {
+		var names []string
+		for _, p := range plugins {
+			names = append(names, p.Name())
+		}
+		return names
+	}
+
+	// slices are in registration order
+	plugins := Get[[]Plugin](injector)
+	assert.Equal(t, []string{"c", "default", "a", "b"}, names(plugins))
+
+	// maps are keyed by binding name
+	byName := Get[map[string]Plugin](injector)
+	assert.Len(t, byName, 4)
+	assert.Equal(t, "default", byName[""].Name())
+	assert.Equal(t, "a", byName["a"].Name())
+	assert.Same(t, NamedGet[Plugin](injector, "b"), byName["b"])
+
+	var resolved []Plugin
+	injector.Resolve(&resolved)
+	assert.Equal(t, names(plugins), names(resolved))
+
+	injector.Call(func(plugins []Plugin, byName map[string]Plugin) {
+		assert.Len(t, plugins, 4)
+		assert.Len(t, byName, 4)
+	})
+
+	injector.Singleton(func(plugins []Plugin) *PluginHost {
+		assert.Len(t, plugins, 4)
+		return &PluginHost{}
+	})
+	host := Get[*PluginHost](injector)
+	assert.Equal(t, names(plugins), names(host.List))
+	assert.Len(t, host.ByName, 4)
+	assert.Equal(t, "default", host.Default.Name())
+	assert.NoError(t, injector.Validate())
+
+	// scopes override inherited bindings of the same name and add their own
+	scope := injector.NewScope()
+	scope.NamedInstance("a", func() Plugin {
+		return &NamedPlugin{name: "scoped-a"}
+	})
+	scope.NamedInstance("d", func() Plugin {
+		return &NamedPlugin{name: "d"}
+	})
+	assert.Equal(t, []string{"c", "default", "b", "scoped-a", "d"}, names(Get[[]Plugin](scope)))
+
+	// unbound types resolve to empty collections
+	assert.Empty(t, Get[[]Database](injector))
+	assert.NotNil(t, Get[map[string]Database](injector))
+}
+
+func TestInjector_Multibindings_Fail(t *testing.T) {
+	var injector = NewInjector()
+	errorCount := 0
+	injector.SetErrorHandler(func(err error) {
+		assert.Error(t, err)
+		errorCount++
+	})
+
+	NamedGet[[]Plugin](injector, "a")
+	assert.Equal(t, 1, errorCount)
+
+	injector.Fill(&struct {
+		Plugins Plugin `di:"all"`
+	}{})
+	assert.Equal(t, 2, errorCount)
+
+	Get[map[int]Plugin](injector)
+	assert.Equal(t, 3, errorCount)
+
+	injector.Singleton(func() *struct {
+		Plugins []string `di:"all"`
+	} {
+		return nil
+	})
+	assert.ErrorIs(t, injector.Validate(), ErrInvalidField)
+}
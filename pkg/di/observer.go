@@ -0,0 +1,186 @@
+package di
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+)
+
+// Event is an activity of the injector reported to its observers. It is one of BindingRegistered, ResolveStarted,
+// ProviderInvoked, InstanceCreated, FieldFilled, ResolveFailed or ScopeClosed.
+type Event interface {
+	event()
+}
+
+// BindingRegistered is reported when a provider is registered with Singleton, Instance, Scoped, Bind or their
+// named variants.
+type BindingRegistered struct {
+	Type     reflect.Type // the type the binding is registered under
+	Name     string       // the binding name, empty for unnamed bindings
+	Lifetime string       // singleton, scoped or instance
+	Provider reflect.Type // the type of the provider function
+	Site     string       // file:line the binding was registered from
+}
+
+// ResolveStarted is reported when the resolution of a binding starts, whether or not its instance already exists.
+type ResolveStarted struct {
+	Type     reflect.Type // the type being resolved
+	Name     string       // the binding name, empty for unnamed bindings
+	Lifetime string       // singleton, scoped or instance
+	Site     string       // file:line the binding was registered from
+	Depth    int          // the number of bindings depending on it within the resolution call
+}
+
+// ProviderInvoked is reported when a provider returns.
+type ProviderInvoked struct {
+	Type     reflect.Type  // the type the provider creates
+	Name     string        // the binding name, empty for unnamed bindings
+	Provider reflect.Type  // the type of the provider function
+	Value    interface{}   // the value returned by the provider, before its fields are filled and it is decorated
+	Err      error         // the error returned by the provider, if any
+	Duration time.Duration // the time the provider took
+}
+
+// InstanceCreated is reported once a new instance is ready, with its fields filled and its decorators applied.
+// Singleton and scoped instances are reported once, when they are created.
+type InstanceCreated struct {
+	Type     reflect.Type // the type of the binding
+	Name     string       // the binding name, empty for unnamed bindings
+	Lifetime string       // singleton, scoped or instance
+	Instance interface{}  // the created instance
+}
+
+// FieldFilled is reported when a field of a structure is set by Fill, or while filling a provided instance.
+type FieldFilled struct {
+	Struct reflect.Type // the type of the structure
+	Field  string       // the name of the field
+	Type   reflect.Type // the type of the field
+	Name   string       // the binding name the field was resolved with, empty for unnamed bindings
+	Value  interface{}  // the value the field was set to
+}
+
+// ResolveFailed is reported when the resolution of a binding fails, including because of one of its dependencies,
+// and when no binding is found for a type.
+type ResolveFailed struct {
+	Type reflect.Type // the type being resolved
+	Name string       // the binding name, empty for unnamed bindings
+	Err  error        // the error of the resolution
+}
+
+// ScopeClosed is reported when a scope created with NewScope is closed, once its instances are released.
+type ScopeClosed struct {
+	Scope *Injector // the closed scope
+	Err   error     // the error returned by Close, if any
+}
+
+func (BindingRegistered) event() {}
+func (ResolveStarted) event()    {}
+func (ProviderInvoked) event()   {}
+func (InstanceCreated) event()   {}
+func (FieldFilled) event()       {}
+func (ResolveFailed) event()     {}
+func (ScopeClosed) event()       {}
+
+// Observer receives the events of an injector. Events are reported synchronously, from the goroutine doing the
+// activity, so observers must be safe for concurrent use and should return quickly. Observers must not resolve
+// from the injector, as events may be reported while a singleton is being created.
+type Observer interface {
+	Observe(event Event)
+}
+
+// ObserverFunc adapts a function to an Observer.
+type ObserverFunc func(event Event)
+
+func (f ObserverFunc) Observe(event Event) {
+	f(event)
+}
+
+// observerEntry identifies an added observer, as observers such as an ObserverFunc are not comparable.
+type observerEntry struct {
+	observer Observer
+}
+
+// AddObserver registers an observer receiving the events of the injector. Events are reported to the observers
+// in the order they were added. The returned function removes the observer.
+// Scopes created afterwards report to the observers of the injector they are created from.
+func (injector *Injector) AddObserver(observer Observer) (remove func()) {
+	if injector.isVerbose() {
+		injector.logCall("AddObserver", debugTypeString(observer))
+	}
+
+	injector.mu.Lock()
+	defer injector.mu.Unlock()
+
+	// the slice is copied rather than appended to, as scopes and reporting goroutines may still hold it
+	entry := &observerEntry{observer: observer}
+	injector.observers = append(append([]*observerEntry{}, injector.observers...), entry)
+
+	return func() {
+		injector.mu.Lock()
+		defer injector.mu.Unlock()
+
+		observers := make([]*observerEntry, 0, len(injector.observers))
+		for _, o := range injector.observers {
+			if o != entry {
+				observers = append(observers, o)
+			}
+		}
+		injector.observers = observers
+	}
+}
+
+// observed returns whether events are logged or observed, so they are only built when needed.
+func (injector *Injector) observed() bool {
+	if injector.isVerbose() {
+		return true
+	}
+
+	injector.mu.RLock()
+	defer injector.mu.RUnlock()
+
+	return len(injector.observers) > 0
+}
+
+// report logs the event if debug logging is enabled and passes it to the observers.
+func (injector *Injector) report(event Event) {
+	if injector.isVerbose() {
+		injector.logEvent(event)
+	}
+
+	injector.mu.RLock()
+	observers := injector.observers
+	injector.mu.RUnlock()
+
+	for _, observer := range observers {
+		observer.observer.Observe(event)
+	}
+}
+
+// failed reports the failed resolution of a type and returns the error.
+func (injector *Injector) failed(typ reflect.Type, name string, err error) error {
+	if injector.observed() {
+		injector.report(ResolveFailed{Type: typ, Name: name, Err: err})
+	}
+	return err
+}
+
+// logEvent is the observer behind the debug logs of the events.
+func (injector *Injector) logEvent(event Event) {
+	switch e := event.(type) {
+	case BindingRegistered:
+		attrs := append(typeAttrs(e.Type, e.Name), slog.String(LifetimeKey, e.Lifetime), slog.String(SiteKey, e.Site))
+		injector.logDebug(eventBind, fmt.Sprintf("%s provider for type `%s` with structure `%s` at %s", e.Lifetime, fullyQualifiedTypeString(e.Type), fullyQualifiedTypeString(e.Provider), e.Site), attrs...)
+	case ResolveStarted:
+		attrs := append(typeAttrs(e.Type, e.Name), slog.String(LifetimeKey, e.Lifetime), slog.String(SiteKey, e.Site))
+		injector.logDebug(eventResolve, fmt.Sprintf("provider for type `%s` registered at %s", fullyQualifiedTypeString(e.Type), e.Site), attrs...)
+	case ProviderInvoked:
+		value := e.Value
+		if e.Err != nil {
+			value = e.Err
+		}
+		injector.logDebug(eventReturn, fmt.Sprintf("value %+v", value), append(typeAttrs(e.Type, e.Name), slog.Duration(DurationKey, e.Duration))...)
+	case ScopeClosed:
+		injector.logDebug(eventClose, "scope")
+	}
+}
@@ -0,0 +1,169 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// eventRecorder is an Observer keeping the events it receives.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) Observe(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+// kinds returns the types of the recorded events and clears them.
+func (r *eventRecorder) kinds() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kinds := []string{}
+	for _, event := range r.events {
+		kinds = append(kinds, fmt.Sprintf("%T", event))
+	}
+	r.events = nil
+	return kinds
+}
+
+func TestInjector_AddObserver(t *testing.T) {
+	var injector = NewInjector()
+	recorder := &eventRecorder{}
+	injector.AddObserver(recorder)
+
+	injector.Singleton(func() *John {
+		return &John{Val: 42}
+	})
+	injector.Instance(func() *Audited {
+		return &Audited{}
+	})
+
+	assert.Equal(t, []string{"di.BindingRegistered", "di.BindingRegistered"}, recorder.kinds())
+
+	Get[*Audited](injector)
+	recorder.mu.Lock()
+	events := recorder.events
+	recorder.mu.Unlock()
+	assert.Equal(t, []string{
+		"di.ResolveStarted",
+		"di.ProviderInvoked",
+		"di.ResolveStarted",
+		"di.ProviderInvoked",
+		"di.InstanceCreated",
+		"di.FieldFilled",
+		"di.InstanceCreated",
+	}, recorder.kinds())
+
+	assert.Equal(t, ResolveStarted{Type: reflect.TypeFor[*Audited](), Lifetime: "instance", Site: events[0].(ResolveStarted).Site, Depth: 0}, events[0])
+	assert.Contains(t, events[0].(ResolveStarted).Site, "observer_test.go:")
+	assert.Equal(t, 1, events[2].(ResolveStarted).Depth)
+	assert.Equal(t, "singleton", events[4].(InstanceCreated).Lifetime)
+	filled := events[5].(FieldFilled)
+	assert.Equal(t, reflect.TypeFor[Audited](), filled.Struct)
+	assert.Equal(t, "John", filled.Field)
+	assert.Equal(t, 42, filled.Value.(*John).Val)
+
+	// the singleton is only created once
+	Get[*Audited](injector)
+	assert.Equal(t, []string{
+		"di.ResolveStarted",
+		"di.ProviderInvoked",
+		"di.ResolveStarted",
+		"di.FieldFilled",
+		"di.InstanceCreated",
+	}, recorder.kinds())
+}
+
+func TestInjector_AddObserver_ResolveFailed(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {})
+	var failures []ResolveFailed
+	var invoked []ProviderInvoked
+	injector.AddObserver(ObserverFunc(func(event Event) {
+		switch e := event.(type) {
+		case ResolveFailed:
+			failures = append(failures, e)
+		case ProviderInvoked:
+			invoked = append(invoked, e)
+		}
+	}))
+
+	failure := errors.New("failure")
+	injector.Instance(func() (*John, error) {
+		return nil, failure
+	})
+	injector.Instance(func(john *John) *Request {
+		return &Request{}
+	})
+
+	// the failure is reported for the binding and the bindings depending on it
+	_, err := TryGet[*Request](injector)
+	assert.ErrorIs(t, err, failure)
+	if assert.Len(t, invoked, 1) {
+		assert.Equal(t, failure, invoked[0].Err)
+	}
+	if assert.Len(t, failures, 2) {
+		assert.Equal(t, reflect.TypeFor[*John](), failures[0].Type)
+		assert.Equal(t, reflect.TypeFor[*Request](), failures[1].Type)
+		assert.ErrorIs(t, failures[1].Err, failure)
+	}
+
+	// missing bindings are reported too, whichever method resolves them
+	failures = nil
+	_, err = TryGet[*Alice](injector)
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, reflect.TypeFor[*Alice](), failures[0].Type)
+		assert.ErrorIs(t, failures[0].Err, ErrMissingProvider)
+	}
+
+	failures = nil
+	var alice *Alice
+	assert.ErrorIs(t, injector.TryNamedResolve(&alice, "missing"), ErrMissingProvider)
+	assert.ErrorIs(t, injector.ResolveContext(context.Background(), &alice), ErrMissingProvider)
+	if assert.Len(t, failures, 2) {
+		assert.Equal(t, ResolveFailed{Type: reflect.TypeFor[*Alice](), Name: "missing", Err: failures[0].Err}, failures[0])
+		assert.ErrorIs(t, failures[0].Err, ErrMissingProvider)
+		assert.Equal(t, reflect.TypeFor[*Alice](), failures[1].Type)
+	}
+}
+
+func TestInjector_AddObserver_Scope(t *testing.T) {
+	var injector = NewInjector()
+	recorder := &eventRecorder{}
+	remove := injector.AddObserver(recorder)
+
+	injector.Scoped(func() *John {
+		return &John{}
+	})
+	recorder.kinds()
+
+	// scopes report to the observers of their parent
+	scope := injector.NewScope()
+	Get[*John](scope)
+	assert.NoError(t, scope.Close(context.Background()))
+	assert.Equal(t, []string{
+		"di.ResolveStarted",
+		"di.ProviderInvoked",
+		"di.InstanceCreated",
+		"di.ScopeClosed",
+	}, recorder.kinds())
+
+	// closing the root injector is not reported
+	assert.NoError(t, injector.Close(context.Background()))
+	assert.Empty(t, recorder.kinds())
+
+	remove()
+	Get[*John](injector.NewScope())
+	assert.Empty(t, recorder.kinds())
+}
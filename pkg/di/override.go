@@ -0,0 +1,134 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+const eventOverride = "override"
+
+// Override temporarily replaces the unnamed binding of the type.
+// See OverrideNamed.
+func Override[Type any](i *Injector, provider interface{}, options ...BindingOption) (restore func(), err error) {
+	return OverrideNamed[Type](i, "", provider, options...)
+}
+
+// OverrideNamed temporarily replaces the binding of the type registered under the name, until restore is called.
+// The provider must return the type. The override keeps the lifetime of the binding it replaces unless a lifetime
+// option is given, and is a singleton if the type was not bound. Decorators registered for the binding apply to the override.
+//
+// Singletons and scoped bindings depending on the type, directly or through other bindings, are reset so that they
+// are created again with the override. Restoring puts back the original bindings, along with the instances they
+// had already created. Entries changed again since the override are left untouched, so overrides of different
+// types can be made and restored independently.
+//
+// Within a scope, the override and the reset dependents shadow the bindings inherited from the parents.
+// It returns a *FrozenError if the injector is frozen, restoring is always allowed.
+func OverrideNamed[Type any](i *Injector, name string, provider interface{}, options ...BindingOption) (restore func(), err error) {
+	typ := reflect.TypeFor[Type]()
+	if i.isVerbose() {
+		i.logCall("OverrideNamed", fullyQualifiedTypeString(typ), fmt.Sprintf("%q", name), debugTypeString(provider))
+	}
+
+	return i.override(typ, name, provider, options)
+}
+
+// override replaces a binding along with its dependents, and returns the function restoring them.
+func (injector *Injector) override(typ reflect.Type, name string, provider interface{}, options []BindingOption) (func(), error) {
+	if injector.isVerbose() {
+		injector.incrementLoggerIndent()
+		defer injector.decrementLoggerIndent()
+	}
+
+	site := callerSite()
+
+	providerType, err := injector.checkProvider(provider, name)
+	if err != nil {
+		return nil, err
+	}
+	if providerType.Out(0) != typ {
+		return nil, injector.errorMiddleWare(&InvalidProviderError{Provider: providerType, Name: name, Reason: fmt.Sprintf("must return `%s`", fullyQualifiedTypeString(typ))})
+	}
+
+	// the entries set by the override, and the entries of the injector they replaced (nil if there were none)
+	installed := map[reflect.Type]map[string]*binding{}
+	original := map[reflect.Type]map[string]*binding{}
+
+	err = injector.writeBindings(func(bindings map[reflect.Type]map[string]*binding) error {
+		if injector.Frozen() {
+			return &FrozenError{Operation: "override", Type: typ, Name: name}
+		}
+
+		set := func(typ reflect.Type, name string, b *binding) {
+			if installed[typ] == nil {
+				installed[typ] = map[string]*binding{}
+				original[typ] = map[string]*binding{}
+			}
+			installed[typ][name] = b
+			original[typ][name] = bindings[typ][name]
+			setBinding(bindings, typ, name, b)
+		}
+
+		existing, exist := injector.lookup(typ, name)
+		btype := Binding_Singleton
+		if exist {
+			btype = existing.btype
+		}
+
+		b := newBinding(provider, name, btype, options)
+		b.name = name
+		b.owner = injector
+		b.site = site
+		if exist {
+			b.decorators = existing.decorators
+		}
+
+		if injector.isVerbose() {
+			injector.logDebug(eventOverride, fmt.Sprintf("%s provider for type `%s` with structure `%s` at %s", b.btype, fullyQualifiedTypeString(typ), fullyQualifiedTypeString(providerType), site), b.attrs()...)
+		}
+
+		// dependents are found before the override is set, as the override may not have the same dependencies
+		dependents := injector.graph().dependents(nodeID(typ, name))
+		set(typ, name, b)
+
+		for _, node := range dependents {
+			dependent, exist := injector.lookup(node.Type, node.Name)
+			if !exist || dependent.btype == Binding_Instance {
+				continue
+			}
+
+			if injector.isVerbose() {
+				injector.logDebug(eventOverride, fmt.Sprintf("%s for type `%s` depending on the override", dependent.btype, fullyQualifiedTypeString(node.Type)), dependent.attrs()...)
+			}
+			set(node.Type, node.Name, dependent.clone(injector))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, injector.errorMiddleWare(err)
+	}
+
+	var once sync.Once
+	restore := func() {
+		once.Do(func() {
+			if injector.isVerbose() {
+				injector.logDebug(eventOverride, fmt.Sprintf("restoring the bindings replaced by the override of type `%s`", fullyQualifiedTypeString(typ)), typeAttrs(typ, name)...)
+			}
+
+			injector.writeBindings(func(bindings map[reflect.Type]map[string]*binding) error {
+				for typ, names := range installed {
+					for name, b := range names {
+						if bindings[typ][name] != b {
+							// changed again since the override
+							continue
+						}
+						setBinding(bindings, typ, name, original[typ][name])
+					}
+				}
+				return nil
+			})
+		})
+	}
+	return restore, nil
+}
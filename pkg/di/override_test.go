@@ -0,0 +1,140 @@
+package di
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Greeter interface {
+	Greet() string
+}
+
+type EnglishGreeter struct{}
+
+func (g *EnglishGreeter) Greet() string {
+	return "hello"
+}
+
+type FrenchGreeter struct{}
+
+func (g *FrenchGreeter) Greet() string {
+	return "bonjour"
+}
+
+type Welcome struct {
+	Greeter Greeter `di:"type"`
+}
+
+type Page struct {
+	Welcome *Welcome `di:"type"`
+}
+
+func TestInjector_Override(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() Greeter {
+		return &EnglishGreeter{}
+	})
+	injector.Singleton(func() *Welcome {
+		return &Welcome{}
+	})
+	injector.Instance(func() *Page {
+		return &Page{}
+	})
+	injector.Singleton(func() *John {
+		return &John{}
+	})
+
+	greeter := Get[Greeter](injector)
+	welcome := Get[*Welcome](injector)
+	john := Get[*John](injector)
+	assert.Equal(t, "hello", Get[*Page](injector).Welcome.Greeter.Greet())
+
+	restore, err := Override[Greeter](injector, func() Greeter {
+		return &FrenchGreeter{}
+	})
+	assert.NoError(t, err)
+
+	// dependents are created again with the override, unrelated singletons are kept
+	assert.Equal(t, "bonjour", Get[*Page](injector).Welcome.Greeter.Greet())
+	assert.NotSame(t, welcome, Get[*Welcome](injector))
+	assert.Same(t, Get[*Welcome](injector), Get[*Welcome](injector))
+	assert.Same(t, john, Get[*John](injector))
+
+	info, _ := injector.Describe(reflect.TypeFor[Greeter](), "")
+	assert.Equal(t, "singleton", info.Lifetime)
+	assert.Contains(t, info.Site, "override_test.go")
+
+	// the original bindings and their instances are restored
+	restore()
+	restore()
+	assert.Same(t, greeter, Get[Greeter](injector))
+	assert.Same(t, welcome, Get[*Welcome](injector))
+	assert.Equal(t, "hello", Get[*Page](injector).Welcome.Greeter.Greet())
+}
+
+func TestInjector_Override_Unbound_And_Scoped(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Scoped(func() *Welcome {
+		return &Welcome{}
+	})
+
+	// overriding a type that is not bound registers it until restored
+	restore, err := Override[Greeter](injector, func() Greeter {
+		return &FrenchGreeter{}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "bonjour", Get[*Welcome](injector.NewScope()).Greeter.Greet())
+	restore()
+	assert.False(t, Has[Greeter](injector))
+
+	// overrides in a scope do not affect the parent
+	injector.Singleton(func() Greeter {
+		return &EnglishGreeter{}
+	})
+	scope := injector.NewScope()
+	restore, err = Override[Greeter](scope, func() Greeter {
+		return &FrenchGreeter{}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "bonjour", Get[*Welcome](scope).Greeter.Greet())
+	assert.Equal(t, "hello", Get[*Welcome](injector.NewScope()).Greeter.Greet())
+	restore()
+	assert.Equal(t, "hello", Get[*Welcome](scope.NewScope()).Greeter.Greet())
+}
+
+func TestInjector_Override_Fail(t *testing.T) {
+	var injector = NewInjector()
+	injector.Singleton(func() Greeter {
+		return &EnglishGreeter{}
+	})
+
+	_, err := Override[Greeter](injector, func() *FrenchGreeter {
+		return &FrenchGreeter{}
+	})
+	assert.ErrorIs(t, err, ErrInvalidProvider)
+
+	// bindings replaced after the override are not restored over
+	restore, err := Override[Greeter](injector, func() Greeter {
+		return &FrenchGreeter{}
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, Replace[Greeter](injector, func() Greeter {
+		return &FrenchGreeter{}
+	}))
+	restore()
+	assert.Equal(t, "bonjour", Get[Greeter](injector).Greet())
+
+	injector.Freeze()
+	_, err = Override[Greeter](injector, func() Greeter {
+		return &EnglishGreeter{}
+	})
+	assert.ErrorIs(t, err, ErrFrozen)
+}
@@ -0,0 +1,112 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// NewScope creates a child injector that inherits the bindings of the injector.
+// Bindings registered in the scope override the inherited ones for resolutions made through the scope.
+// Scoped bindings are shared within a scope but not across scopes, and are released when the scope is closed with Close.
+// Singletons are shared with the parent and have their dependencies resolved from the injector they are registered in.
+func (injector *Injector) NewScope() *Injector {
+	if injector.isVerbose() {
+		injector.logCall("NewScope")
+	}
+
+	injector.mu.RLock()
+	defer injector.mu.RUnlock()
+
+	scope := NewInjector()
+	scope.parent = injector
+	scope.verbose = atomic.LoadInt32(&injector.verbose)
+	scope.timeout = atomic.LoadInt64(&injector.timeout)
+	scope.slowThreshold = atomic.LoadInt64(&injector.slowThreshold)
+	scope.errHandler = injector.errHandler
+	scope.logger = injector.logger
+	scope.slogger = injector.slogger
+	scope.observers = injector.observers
+	scope.metrics = injector.metrics
+	scope.sink = injector.sink
+	return scope
+}
+
+// Parent returns the injector the scope was created from, or nil if the injector is not a scope.
+func (injector *Injector) Parent() *Injector {
+	return injector.parent
+}
+
+// Scoped binds an abstraction to concrete for resolves shared within a scope.
+// Each scope created with NewScope holds its own instance, which is released when the scope is closed.
+// Resolving a scoped binding outside of a scope shares the instance within the root injector.
+func (injector *Injector) Scoped(provider interface{}, options ...BindingOption) *Injector {
+	if injector.isVerbose() {
+		injector.logCall("Scoped", debugTypeString(provider))
+	}
+
+	err := injector.bind(provider, "", Binding_Scoped, options)
+	if err != nil {
+		injector.handleError(err)
+	}
+
+	return injector
+}
+
+// NamedScoped binds like the Scoped method but for named bindings.
+func (injector *Injector) NamedScoped(name string, provider interface{}, options ...BindingOption) *Injector {
+	if injector.isVerbose() {
+		injector.logCall("NamedScoped", fmt.Sprintf("%q", name), debugTypeString(provider))
+	}
+
+	err := injector.bind(provider, name, Binding_Scoped, options)
+	if err != nil {
+		injector.handleError(err)
+	}
+
+	return injector
+}
+
+// visibleBindings returns the bindings of the injector merged with those inherited from its parents.
+// Bindings registered in a scope override the inherited bindings of the same type and name.
+func (injector *Injector) visibleBindings() map[reflect.Type]map[string]*binding {
+	bindings := map[reflect.Type]map[string]*binding{}
+	for i := injector; i != nil; i = i.parent {
+		for typ, named := range i.loadBindings() {
+			if bindings[typ] == nil {
+				bindings[typ] = map[string]*binding{}
+			}
+			for name, b := range named {
+				if _, overridden := bindings[typ][name]; !overridden {
+					bindings[typ][name] = b
+				}
+			}
+		}
+	}
+	return bindings
+}
+
+// scopedBinding returns the copy of a scoped binding that holds the instance for this scope.
+func (injector *Injector) scopedBinding(b *binding) *binding {
+	injector.scopedMu.Lock()
+	defer injector.scopedMu.Unlock()
+
+	scoped, exist := injector.scoped[b]
+	if !exist {
+		scoped = b.clone(injector)
+		injector.scoped[b] = scoped
+	}
+	return scoped
+}
+
+// clone copies a binding without its instance, for the given injector.
+func (b *binding) clone(owner *Injector) *binding {
+	copied := *b
+	copied.mu = newContextMutex()
+	copied.instance = nil
+	copied.created = new(atomic.Bool)
+	copied.closed = nil
+	copied.started = nil
+	copied.owner = owner
+	return &copied
+}
@@ -0,0 +1,142 @@
+package di
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Tx struct {
+	id     int
+	closed bool
+}
+
+func (tx *Tx) Close() error {
+	tx.closed = true
+	return nil
+}
+
+type Handler struct {
+	Tx   *Tx      `di:"type"`
+	DB   Database `di:"type"`
+	Name *John    `di:"type"`
+}
+
+func TestInjector_Scope(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+
+	ids := 0
+	injector.Scoped(func() *Tx {
+		ids++
+		return &Tx{id: ids}
+	})
+	injector.Singleton(func() Database {
+		return &MySQL{}
+	})
+	injector.Instance(func() *Handler {
+		return &Handler{}
+	})
+	injector.Singleton(func() *John {
+		return &John{Val: 1}
+	})
+
+	scopeA := injector.NewScope()
+	scopeB := injector.NewScope()
+	assert.Same(t, injector, scopeA.Parent())
+
+	// scoped bindings are shared within a scope
+	txA := Get[*Tx](scopeA)
+	assert.Same(t, txA, Get[*Tx](scopeA))
+	handler := Get[*Handler](scopeA)
+	assert.Same(t, txA, handler.Tx)
+
+	// but not across scopes
+	txB := Get[*Tx](scopeB)
+	assert.NotSame(t, txA, txB)
+	assert.NotSame(t, txA, Get[*Tx](injector))
+
+	// singletons are shared with the parent
+	assert.Same(t, Get[Database](injector), Get[Database](scopeA))
+	assert.Same(t, Get[Database](scopeA), Get[Database](scopeB))
+
+	// scopes can override bindings
+	scopeB.Singleton(func() *John {
+		return &John{Val: 2}
+	})
+	assert.Equal(t, 2, Get[*Handler](scopeB).Name.Val)
+	assert.Equal(t, 1, Get[*Handler](scopeA).Name.Val)
+	assert.Equal(t, 1, Get[*John](injector).Val)
+
+	// nested scopes fall back through every parent
+	nested := scopeB.NewScope()
+	assert.Equal(t, 2, Get[*John](nested).Val)
+	assert.NotSame(t, txB, Get[*Tx](nested))
+
+	// closing a scope releases its scoped instances only
+	assert.NoError(t, scopeA.Close(context.Background()))
+	assert.True(t, txA.closed)
+	assert.False(t, txB.closed)
+	assert.NotSame(t, txA, Get[*Tx](scopeA))
+}
+
+func TestInjector_Scope_Named(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.NamedScoped("read", func() *Tx {
+		return &Tx{id: 1}
+	})
+	injector.NamedScoped("write", func() *Tx {
+		return &Tx{id: 2}
+	})
+
+	scope := injector.NewScope()
+	read := NamedGet[*Tx](scope, "read")
+	assert.Equal(t, 1, read.id)
+	assert.Same(t, read, NamedGet[*Tx](scope, "read"))
+	assert.Equal(t, 2, NamedGet[*Tx](scope, "write").id)
+
+	_, err := TryGet[*Tx](scope)
+	assert.ErrorIs(t, err, ErrMissingProvider)
+}
+
+type TxService struct {
+	Tx *Tx
+}
+
+func TestInjector_Scope_Singleton_Scoped_Dependency(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+
+	ids := 0
+	injector.Scoped(func() *Tx {
+		ids++
+		return &Tx{id: ids}
+	})
+	injector.Singleton(func(tx *Tx) *TxService {
+		return &TxService{Tx: tx}
+	})
+
+	// the singleton resolves the scoped binding from the injector it is registered in,
+	// even when the scope resolved it earlier in the same call
+	var scopeTx, serviceTx *Tx
+	scope := injector.NewScope()
+	scope.Call(func(tx *Tx, service *TxService) {
+		scopeTx, serviceTx = tx, service.Tx
+	})
+	assert.NotSame(t, scopeTx, serviceTx)
+	assert.Same(t, Get[*Tx](injector), serviceTx)
+
+	other := injector.NewScope()
+	other.Call(func(tx *Tx, service *TxService) {
+		assert.NotSame(t, scopeTx, tx)
+		assert.Same(t, serviceTx, service.Tx)
+	})
+}
@@ -0,0 +1,36 @@
+package di
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// packageDir is the source directory of this package, frames within it are skipped when recording call sites.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerSite returns the file:line of the first caller outside of this package (and its subpackages),
+// which is where a binding is being registered from.
+func callerSite() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isInternalFile(frame.File) {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// isInternalFile reports whether a source file belongs to this package or its subpackages, excluding tests.
+func isInternalFile(file string) bool {
+	return strings.HasPrefix(file, packageDir+"/") && !strings.HasSuffix(file, "_test.go")
+}
@@ -0,0 +1,178 @@
+package di
+
+import (
+	"reflect"
+)
+
+// InjectorSnapshot is the state of an injector captured by Snapshot, which can be restored with Restore.
+type InjectorSnapshot struct {
+	owner    *Injector                            // the injector the snapshot was taken from
+	bindings map[reflect.Type]map[string]*binding // copies of the bindings holding their instances
+	scoped   map[*binding]*binding                // copies of the scoped instances of the injector
+	created  []*binding                           // copies of the bindings whose instances were created, in creation order
+}
+
+// Snapshot captures the bindings registered in the injector along with the instances they have created,
+// so the injector can be rolled back to this state with Restore. Instances are shared with the snapshot, not copied.
+// A snapshot of a scope holds the bindings registered in the scope and its scoped instances, not those of its parents.
+func (injector *Injector) Snapshot() *InjectorSnapshot {
+	if injector.isVerbose() {
+		injector.logCall("Snapshot")
+	}
+
+	snap := &InjectorSnapshot{
+		owner:    injector,
+		bindings: map[reflect.Type]map[string]*binding{},
+		scoped:   map[*binding]*binding{},
+	}
+	copies := map[*binding]*binding{}
+	capture := func(b *binding) *binding {
+		copied, exist := copies[b]
+		if !exist {
+			b.mu.Lock()
+			copied = b.clone(b.owner)
+			copied.instance = b.instance
+			copied.created.Store(b.instance != nil)
+			copied.closed = b.closed
+			copied.started = b.started
+			copied.decorators = b.decorators.copy()
+			b.mu.Unlock()
+
+			copies[b] = copied
+		}
+		return copied
+	}
+
+	for typ, names := range injector.loadBindings() {
+		snap.bindings[typ] = make(map[string]*binding, len(names))
+		for name, b := range names {
+			snap.bindings[typ][name] = capture(b)
+		}
+	}
+
+	injector.scopedMu.Lock()
+	scoped := make(map[*binding]*binding, len(injector.scoped))
+	for b, s := range injector.scoped {
+		scoped[b] = s
+	}
+	injector.scopedMu.Unlock()
+
+	for b, s := range scoped {
+		// scoped bindings inherited from a parent are not part of the snapshot, so they keep their key
+		key := b
+		if copied, exist := copies[b]; exist {
+			key = copied
+		}
+		snap.scoped[key] = capture(s)
+	}
+
+	injector.createdMu.Lock()
+	created := append([]*binding(nil), injector.created...)
+	injector.createdMu.Unlock()
+
+	for _, b := range created {
+		snap.created = append(snap.created, capture(b))
+	}
+
+	return snap
+}
+
+// Restore rolls the injector back to the state captured by Snapshot, replacing every binding registered since and
+// bringing back the instances created at the time of the snapshot. The bindings are replaced at once, so concurrent
+// resolutions see either the current or the restored bindings. A snapshot can be restored any number of times.
+// Instances created since the snapshot are no longer resolved, but are still released by Close. Instances of the
+// snapshot that Close has released since are not brought back, their bindings create new ones when next resolved.
+// It returns a *FrozenError if the injector is frozen.
+func (injector *Injector) Restore(snap *InjectorSnapshot) error {
+	if injector.isVerbose() {
+		injector.logCall("Restore")
+	}
+
+	if snap == nil {
+		return injector.errorMiddleWare(&InvalidArgumentError{Reason: "the snapshot must not be nil"})
+	}
+	if snap.owner != injector {
+		return injector.errorMiddleWare(&InvalidArgumentError{Type: reflect.TypeOf(snap), Reason: "the snapshot was taken from another injector"})
+	}
+
+	// copy the snapshot again, so it is not changed by the restored bindings
+	copies := map[*binding]*binding{}
+	restore := func(b *binding) *binding {
+		copied, exist := copies[b]
+		if !exist {
+			copied = b.clone(b.owner)
+			// instances released by Close since the snapshot are created anew
+			if !b.released() {
+				copied.instance = b.instance
+				copied.created.Store(b.instance != nil)
+				copied.closed = b.closed
+				copied.started = b.started
+			}
+			copied.decorators = b.decorators.copy()
+			copies[b] = copied
+		}
+		return copied
+	}
+
+	bindings := make(map[reflect.Type]map[string]*binding, len(snap.bindings))
+	for typ, names := range snap.bindings {
+		bindings[typ] = make(map[string]*binding, len(names))
+		for name, b := range names {
+			bindings[typ][name] = restore(b)
+		}
+	}
+
+	scoped := make(map[*binding]*binding, len(snap.scoped))
+	for b, s := range snap.scoped {
+		key := b
+		if copied, exist := copies[b]; exist {
+			// the scoped binding is registered in the injector rather than inherited
+			key = copied
+		}
+		scoped[key] = restore(s)
+	}
+
+	// copies keep the registration number of the binding they were made from
+	created := make([]*binding, 0, len(snap.created))
+	createdBefore := make(map[uint64]bool, len(snap.created))
+	for _, b := range snap.created {
+		if restored := restore(b); restored.instance != nil {
+			created = append(created, restored)
+		}
+		createdBefore[b.id] = true
+	}
+
+	err := injector.writeBindings(func(current map[reflect.Type]map[string]*binding) error {
+		if injector.Frozen() {
+			return &FrozenError{Operation: "restore"}
+		}
+
+		for typ := range current {
+			delete(current, typ)
+		}
+		for typ, names := range bindings {
+			current[typ] = names
+		}
+
+		injector.scopedMu.Lock()
+		injector.scoped = scoped
+		injector.scopedMu.Unlock()
+
+		// instances created since the snapshot are kept, after the restored ones, so Close still releases them
+		injector.createdMu.Lock()
+		for _, b := range injector.created {
+			if !createdBefore[b.id] {
+				created = append(created, b)
+			}
+		}
+		injector.created = created
+		injector.createdMu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		return injector.errorMiddleWare(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package di
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjector_Snapshot(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() Greeter {
+		return &EnglishGreeter{}
+	})
+	injector.Singleton(func() *Welcome {
+		return &Welcome{}
+	})
+	injector.Scoped(func() *John {
+		return &John{}
+	})
+
+	welcome := Get[*Welcome](injector)
+	john := Get[*John](injector)
+	snap := injector.Snapshot()
+
+	// changes made after the snapshot
+	assert.NoError(t, Replace[Greeter](injector, func() Greeter {
+		return &FrenchGreeter{}
+	}))
+	assert.NoError(t, Unbind[*Welcome](injector))
+	injector.Singleton(func() *C {
+		return &C{}
+	})
+	c := Get[*C](injector)
+
+	assert.NoError(t, injector.Restore(snap))
+	assert.False(t, Has[*C](injector))
+	assert.Same(t, welcome, Get[*Welcome](injector))
+	assert.Same(t, john, Get[*John](injector))
+	assert.Equal(t, "hello", Get[Greeter](injector).Greet())
+
+	// the snapshot can be restored again, and is not changed by the restored bindings
+	assert.NoError(t, Unbind[*Welcome](injector))
+	assert.NoError(t, injector.Restore(snap))
+	assert.Same(t, welcome, Get[*Welcome](injector))
+
+	// every instance is released once, including those created since the snapshot
+	closed := map[interface{}]int{}
+	for _, b := range injector.created {
+		closed[b.instance]++
+	}
+	assert.Len(t, closed, 4)
+	assert.Equal(t, 1, closed[c])
+	assert.Equal(t, 1, closed[welcome])
+	assert.NoError(t, injector.Close(context.Background()))
+
+	injector.Freeze()
+	assert.ErrorIs(t, injector.Restore(snap), ErrFrozen)
+	assert.ErrorIs(t, injector.Restore(NewInjector().Snapshot()), ErrInvalidArgument)
+	assert.ErrorIs(t, injector.Restore(nil), ErrInvalidArgument)
+}
+
+func TestInjector_Snapshot_Concurrent_Restore(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() Greeter {
+		return &EnglishGreeter{}
+	})
+	injector.Singleton(func() *Welcome {
+		return &Welcome{}
+	})
+	english := injector.Snapshot()
+	assert.NoError(t, Replace[Greeter](injector, func() Greeter {
+		return &FrenchGreeter{}
+	}))
+	french := injector.Snapshot()
+
+	const workers = 8
+	const iterations = 50
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				if w == 0 {
+					snap := english
+					if i%2 == 0 {
+						snap = french
+					}
+					assert.NoError(t, injector.Restore(snap))
+					continue
+				}
+
+				// the bindings are always consistent with one of the snapshots
+				greeting := Get[*Welcome](injector).Greeter.Greet()
+				assert.Contains(t, []string{"hello", "bonjour"}, greeting)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+func TestInjector_Restore_After_Close(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	ids := 0
+	injector.Singleton(func() *Tx {
+		ids++
+		return &Tx{id: ids}
+	})
+
+	closed := Get[*Tx](injector)
+	snap := injector.Snapshot()
+	assert.NoError(t, injector.Close(context.Background()))
+	assert.True(t, closed.closed)
+
+	// the instance released by Close is not brought back
+	assert.NoError(t, injector.Restore(snap))
+	tx := Get[*Tx](injector)
+	assert.NotSame(t, closed, tx)
+	assert.False(t, tx.closed)
+
+	assert.NoError(t, injector.Close(context.Background()))
+	assert.True(t, tx.closed)
+}
@@ -0,0 +1,91 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// WithTimeout bounds every resolution of the binding, including the wait for another caller creating a singleton
+// and the resolution of its dependencies. Providers taking a context.Context receive the deadline.
+// Once the timeout passes the resolution fails with a *CanceledError wrapping context.DeadlineExceeded, also when
+// a provider not watching the context returns after it, in which case the value of the provider is released
+// with the OnClose hook of the binding, or its own Close or Stop method, and discarded.
+func WithTimeout(timeout time.Duration) BindingOption {
+	return func(b *binding) {
+		b.timeout = timeout
+	}
+}
+
+// SetTimeout bounds every resolution call made through the injector, such as Get, Resolve, Fill or Call,
+// and each singleton created by Start. A timeout of zero, the default, disables it.
+// Scopes created afterwards inherit the timeout.
+func (injector *Injector) SetTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&injector.timeout, int64(timeout))
+}
+
+// SetSlowProviderThreshold logs a warning through the logger of the injector, whether or not debug logging is
+// enabled, for every provider taking longer than the threshold to return. The warning holds the type, the binding
+// name and the time the provider took. A threshold of zero, the default, disables it.
+// Scopes created afterwards inherit the threshold.
+func (injector *Injector) SetSlowProviderThreshold(threshold time.Duration) {
+	atomic.StoreInt64(&injector.slowThreshold, int64(threshold))
+}
+
+// startResolution starts a resolution call, bounded by the timeout of the injector if one is set.
+func (injector *Injector) startResolution(ctx context.Context) (*resolution, context.CancelFunc) {
+	if timeout := time.Duration(atomic.LoadInt64(&injector.timeout)); timeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		return newResolution(ctx), cancel
+	}
+	return newResolution(ctx), func() {}
+}
+
+// expired returns the error of the context once it is done or its deadline has passed, as the context is only
+// canceled shortly after its deadline.
+func expired(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+// discard releases the value of a provider that returned after the resolution expired, as no caller receives it.
+func (injector *Injector) discard(b *binding, value interface{}, state *resolution) {
+	if value == nil || reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil() {
+		return
+	}
+
+	// the context of the resolution is done, but releasing the value should not be cut short
+	if err := b.close(context.WithoutCancel(state.ctx), value); err != nil {
+		typ := reflect.TypeOf(b.provider).Out(0)
+		injector.logWarning(eventClose, fmt.Sprintf("releasing the discarded value of type `%s`%s%s failed: %s", fullyQualifiedTypeString(typ), nameString(b.name), registeredAt(b.site), err), b.attrs()...)
+	}
+}
+
+// callProvider invokes a provider with its resolved arguments, measures it and warns if it is slower than the threshold.
+func (injector *Injector) callProvider(function interface{}, args []reflect.Value, name string, state *resolution) ([]reflect.Value, time.Duration) {
+	start := time.Now()
+	values := reflect.ValueOf(function).Call(args)
+	elapsed := time.Since(start)
+
+	injector.sinks(func(sink MetricsSink) {
+		sink.ProviderCalled(reflect.TypeOf(function).Out(0), name, elapsed)
+	})
+
+	if threshold := time.Duration(atomic.LoadInt64(&injector.slowThreshold)); threshold > 0 && elapsed > threshold {
+		typ := reflect.TypeOf(function).Out(0)
+		attrs := append(typeAttrs(typ, name), slog.Duration(DurationKey, elapsed))
+		if site := state.site(); site != "" {
+			attrs = append(attrs, slog.String(SiteKey, site))
+		}
+		injector.logWarning(eventInvoke, fmt.Sprintf("provider for type `%s`%s%s took %s, longer than the threshold of %s", fullyQualifiedTypeString(typ), nameString(name), registeredAt(state.site()), elapsed, threshold), attrs...)
+	}
+	return values, elapsed
+}
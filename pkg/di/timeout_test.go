@@ -0,0 +1,167 @@
+package di
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjector_WithTimeout(t *testing.T) {
+	var injector = NewInjector()
+	injector.Singleton(func(ctx context.Context) (*John, error) {
+		// the provider receives the deadline of the binding
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, WithTimeout(10*time.Millisecond))
+	injector.Singleton(func(john *John) *Alice {
+		return &Alice{John: john}
+	})
+
+	_, err := TryGet[*Alice](injector)
+	assert.ErrorIs(t, err, ErrProviderFailed)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestInjector_WithTimeout_Singleton_Wait(t *testing.T) {
+	var injector = NewInjector()
+	started, release := make(chan struct{}), make(chan struct{})
+	calls := 0
+	injector.Singleton(func() *John {
+		calls++
+		if calls == 1 {
+			close(started)
+			<-release
+		}
+		return &John{Val: calls}
+	}, WithTimeout(10*time.Millisecond))
+
+	done := make(chan error)
+	go func() {
+		_, err := TryGet[*John](injector)
+		done <- err
+	}()
+	<-started
+
+	// callers waiting for the slow provider give up after the timeout
+	_, err := TryGet[*John](injector)
+	assert.ErrorIs(t, err, ErrCanceled)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the provider already running is not interrupted, but its value is discarded
+	close(release)
+	assert.ErrorIs(t, <-done, ErrCanceled)
+	assert.Equal(t, 2, Get[*John](injector).Val)
+}
+
+func TestInjector_SetTimeout(t *testing.T) {
+	var injector = NewInjector()
+	injector.Instance(func(ctx context.Context) (*John, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return &John{}, nil
+		}
+	})
+
+	injector.SetTimeout(10 * time.Millisecond)
+	_, err := TryGet[*John](injector)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// scopes inherit the timeout
+	err = injector.NewScope().TryCall(func(john *John) {})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// a shorter deadline of the caller still applies
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = GetContext[*John](ctx, injector)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestInjector_SetSlowProviderThreshold(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	var buf bytes.Buffer
+	injector.SetSlogLogger(slog.New(NewPrettyHandler(&buf, nil)))
+
+	injector.NamedSingleton("slow", func() *John {
+		time.Sleep(20 * time.Millisecond)
+		return &John{}
+	})
+	injector.Singleton(func() *Request {
+		return &Request{}
+	})
+
+	injector.SetSlowProviderThreshold(10 * time.Millisecond)
+	Get[*Request](injector)
+	assert.Empty(t, buf.String())
+
+	NamedGet[*John](injector, "slow")
+	assert.Contains(t, buf.String(), "WARNING")
+	assert.Contains(t, buf.String(), "*di.John")
+	assert.Contains(t, buf.String(), "under name `slow`")
+	assert.Contains(t, buf.String(), "timeout_test.go:")
+	assert.Contains(t, buf.String(), "longer than the threshold of 10ms")
+}
+
+func TestInjector_WithTimeout_Overrun(t *testing.T) {
+	var injector = NewInjector()
+	injector.Instance(func() *John {
+		// the provider ignores the deadline
+		time.Sleep(50 * time.Millisecond)
+		return &John{}
+	}, WithTimeout(5*time.Millisecond))
+
+	_, err := TryGet[*John](injector)
+	assert.ErrorIs(t, err, ErrCanceled)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	injector.Instance(func() (*John, error) {
+		time.Sleep(50 * time.Millisecond)
+		return &John{}, nil
+	})
+	injector.SetTimeout(5 * time.Millisecond)
+	_, err = TryGet[*John](injector)
+	assert.ErrorIs(t, err, ErrCanceled)
+}
+
+func TestInjector_WithTimeout_Overrun_Release(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {})
+	var closed []string
+	injector.Singleton(func() *Pool {
+		time.Sleep(50 * time.Millisecond)
+		return &Pool{closed: &closed, name: "pool"}
+	}, WithTimeout(5*time.Millisecond))
+
+	// the discarded value is released, as no caller receives it
+	_, err := TryGet[*Pool](injector)
+	assert.ErrorIs(t, err, ErrCanceled)
+	assert.Equal(t, []string{"pool"}, closed)
+
+	// the OnClose hook is used instead, with a context that is not done
+	var hooked error
+	injector.Instance(func() (*Cache, error) {
+		time.Sleep(50 * time.Millisecond)
+		return &Cache{closed: &closed}, nil
+	}, WithTimeout(5*time.Millisecond), OnClose(func(ctx context.Context, instance interface{}) error {
+		hooked = ctx.Err()
+		closed = append(closed, "hook")
+		return nil
+	}))
+	_, err = TryGet[*Cache](injector)
+	assert.ErrorIs(t, err, ErrCanceled)
+	assert.Equal(t, []string{"pool", "hook"}, closed)
+	assert.NoError(t, hooked)
+
+	// nothing is left for Close to release
+	assert.NoError(t, injector.Close(context.Background()))
+	assert.Equal(t, []string{"pool", "hook"}, closed)
+}
@@ -0,0 +1,124 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	eventUnbind  = "unbind"
+	eventReplace = "replace"
+)
+
+// Unbind removes the unnamed binding of the type from the injector.
+// See UnbindNamed.
+func Unbind[Type any](i *Injector) error {
+	return UnbindNamed[Type](i, "")
+}
+
+// UnbindNamed removes the binding of the type registered under the name.
+// Only bindings registered in the injector itself can be removed, not those inherited by a scope.
+// Instances already created by the binding are discarded, they are no longer resolved but are still released by Close.
+// It returns a *MissingProviderError if nothing is bound, or a *FrozenError if the injector is frozen.
+func UnbindNamed[Type any](i *Injector, name string) error {
+	typ := reflect.TypeFor[Type]()
+	if i.isVerbose() {
+		i.logCall("UnbindNamed", fullyQualifiedTypeString(typ), fmt.Sprintf("%q", name))
+	}
+
+	return i.unbind(typ, name)
+}
+
+// Replace replaces the provider of the unnamed binding of the type.
+// See ReplaceNamed.
+func Replace[Type any](i *Injector, provider interface{}, options ...BindingOption) error {
+	return ReplaceNamed[Type](i, "", provider, options...)
+}
+
+// ReplaceNamed replaces the provider of the binding of the type registered under the name.
+// The provider must return the type. The binding keeps its lifetime unless a lifetime option is given.
+// Only bindings registered in the injector itself can be replaced, not those inherited by a scope, and the
+// overwrite policy does not apply.
+// Instances already created by the replaced binding are discarded, they are no longer resolved but are still released by Close.
+// Decorators registered for the binding apply to the new provider.
+// It returns a *MissingProviderError if nothing is bound, or a *FrozenError if the injector is frozen.
+func ReplaceNamed[Type any](i *Injector, name string, provider interface{}, options ...BindingOption) error {
+	typ := reflect.TypeFor[Type]()
+	if i.isVerbose() {
+		i.logCall("ReplaceNamed", fullyQualifiedTypeString(typ), fmt.Sprintf("%q", name), debugTypeString(provider))
+	}
+
+	return i.replace(typ, name, provider, options)
+}
+
+// unbind removes a binding registered in the injector.
+func (injector *Injector) unbind(typ reflect.Type, name string) error {
+	err := injector.writeBindings(func(bindings map[reflect.Type]map[string]*binding) error {
+		if injector.Frozen() {
+			return &FrozenError{Operation: "unbind", Type: typ, Name: name}
+		}
+		if _, exist := bindings[typ][name]; !exist {
+			return &MissingProviderError{Type: typ, Name: name}
+		}
+
+		if injector.isVerbose() {
+			injector.logDebug(eventUnbind, fmt.Sprintf("provider for type `%s`", fullyQualifiedTypeString(typ)), typeAttrs(typ, name)...)
+		}
+
+		setBinding(bindings, typ, name, nil)
+		return nil
+	})
+	if err != nil {
+		return injector.errorMiddleWare(err)
+	}
+
+	return nil
+}
+
+// replace replaces the provider of a binding registered in the injector.
+func (injector *Injector) replace(typ reflect.Type, name string, provider interface{}, options []BindingOption) error {
+	if injector.isVerbose() {
+		injector.incrementLoggerIndent()
+		defer injector.decrementLoggerIndent()
+	}
+
+	site := callerSite()
+
+	providerType, err := injector.checkProvider(provider, name)
+	if err != nil {
+		return err
+	}
+	if providerType.Out(0) != typ {
+		return injector.errorMiddleWare(&InvalidProviderError{Provider: providerType, Name: name, Reason: fmt.Sprintf("must return `%s`", fullyQualifiedTypeString(typ))})
+	}
+
+	err = injector.writeBindings(func(bindings map[reflect.Type]map[string]*binding) error {
+		if injector.Frozen() {
+			return &FrozenError{Operation: "replace", Type: typ, Name: name}
+		}
+		existing, exist := bindings[typ][name]
+		if !exist {
+			return &MissingProviderError{Type: typ, Name: name}
+		}
+
+		b := newBinding(provider, name, existing.btype, options)
+		b.name = name
+		b.owner = injector
+		b.site = site
+		b.decorators = existing.decorators
+		// the replacement takes the place of the binding among the multibindings
+		b.seq = existing.seq
+
+		if injector.isVerbose() {
+			injector.logDebug(eventReplace, fmt.Sprintf("%s provider for type `%s` with structure `%s` at %s", b.btype, fullyQualifiedTypeString(typ), fullyQualifiedTypeString(providerType), site), b.attrs()...)
+		}
+
+		setBinding(bindings, typ, name, b)
+		return nil
+	})
+	if err != nil {
+		return injector.errorMiddleWare(err)
+	}
+
+	return nil
+}
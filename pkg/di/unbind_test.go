@@ -0,0 +1,154 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjector_Unbind(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	closed := 0
+	injector.Singleton(func() Database {
+		return &MySQL{}
+	}, OnClose(func(ctx context.Context, instance interface{}) error {
+		closed++
+		return nil
+	}))
+	injector.NamedSingleton("replica", func() Database {
+		return &MySQL{}
+	})
+
+	Get[Database](injector)
+	assert.NoError(t, Unbind[Database](injector))
+	assert.False(t, Has[Database](injector))
+	assert.True(t, HasNamed[Database](injector, "replica"))
+
+	assert.NoError(t, UnbindNamed[Database](injector, "replica"))
+	assert.Empty(t, injector.Bindings())
+
+	err := Unbind[Database](injector)
+	assert.ErrorIs(t, err, ErrMissingProvider)
+
+	// instances created by an unbound binding are still released
+	assert.NoError(t, injector.Close(context.Background()))
+	assert.Equal(t, 1, closed)
+
+	// inherited bindings cannot be unbound from a scope
+	injector.Singleton(func() Database {
+		return &MySQL{}
+	})
+	scope := injector.NewScope()
+	assert.ErrorIs(t, Unbind[Database](scope), ErrMissingProvider)
+
+	injector.Freeze()
+	err = Unbind[Database](injector)
+	var frozen *FrozenError
+	if assert.True(t, errors.As(err, &frozen)) {
+		assert.Equal(t, "unbind", frozen.Operation)
+	}
+	assert.True(t, Has[Database](injector))
+}
+
+func TestInjector_Replace(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	injector.Singleton(func() *John {
+		return &John{Val: 1}
+	})
+	injector.NamedInstance("two", func() (*John, error) {
+		return &John{Val: 2}, nil
+	})
+
+	first := Get[*John](injector)
+	assert.Equal(t, 1, first.Val)
+
+	// the cached instance is discarded, and the lifetime is kept
+	assert.NoError(t, Replace[*John](injector, func() *John {
+		return &John{Val: 10}
+	}))
+	replaced := Get[*John](injector)
+	assert.Equal(t, 10, replaced.Val)
+	assert.Same(t, replaced, Get[*John](injector))
+
+	info, _ := injector.Describe(reflect.TypeFor[*John](), "")
+	assert.Equal(t, "singleton", info.Lifetime)
+	assert.Contains(t, info.Site, "unbind_test.go")
+
+	// lifetime options replace the lifetime
+	assert.NoError(t, ReplaceNamed[*John](injector, "two", func() *John {
+		return &John{Val: 20}
+	}, AsSingleton()))
+	assert.Same(t, NamedGet[*John](injector, "two"), NamedGet[*John](injector, "two"))
+	assert.Equal(t, 20, NamedGet[*John](injector, "two").Val)
+
+	err := ReplaceNamed[*John](injector, "three", func() *John {
+		return &John{}
+	})
+	assert.ErrorIs(t, err, ErrMissingProvider)
+
+	err = Replace[*John](injector, func() *C {
+		return &C{}
+	})
+	assert.ErrorIs(t, err, ErrInvalidProvider)
+
+	injector.Freeze()
+	err = Replace[*John](injector, func() *John {
+		return &John{}
+	})
+	assert.ErrorIs(t, err, ErrFrozen)
+	assert.Same(t, replaced, Get[*John](injector))
+}
+
+func TestInjector_Two_Value_Providers_Bind_Only_Their_Type(t *testing.T) {
+	var injector = NewInjector()
+	var errs []error
+	injector.SetErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})
+	injector.Instance(func() (*John, error) {
+		return &John{}, nil
+	})
+	assert.True(t, Has[*John](injector))
+	assert.False(t, Has[error](injector))
+	assert.Len(t, injector.Bindings(), 1)
+
+	injector.Instance(func() (*C, *John) {
+		return &C{}, &John{}
+	})
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], ErrInvalidProvider)
+	}
+}
+
+func TestInjector_Replace_Multibinding_Order(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	for _, name := range []string{"a", "b", "c"} {
+		name := name
+		injector.NamedInstance(name, func() Plugin {
+			return &NamedPlugin{name: name}
+		})
+	}
+
+	// the replacement keeps the position of the binding it replaces
+	assert.NoError(t, ReplaceNamed[Plugin](injector, "a", func() Plugin {
+		return &NamedPlugin{name: "a2"}
+	}))
+
+	names := []string{}
+	for _, plugin := range Get[[]Plugin](injector) {
+		names = append(names, plugin.Name())
+	}
+	assert.Equal(t, []string{"a2", "b", "c"}, names)
+}
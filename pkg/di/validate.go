@@ -0,0 +1,147 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrValidation is matched by errors returned from Validate, for use with errors.Is.
+var ErrValidation = errors.New("di: validation failed")
+
+// ValidationError aggregates every problem found by Validate.
+type ValidationError struct {
+	Errors []error // the problems found, ordered by type and binding name
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		lines[i] = "  - " + err.Error()
+	}
+	return fmt.Sprintf("validation found %d problem(s):\n%s", len(e.Errors), strings.Join(lines, "\n"))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// Validate checks every registered binding without invoking any provider, including on a scope the bindings inherited
+// from its parents. Singletons are checked against the injector they are registered in, as their dependencies come from it.
+// The arguments of each provider must be bound, and when a provider returns a pointer to a struct
+// its tagged fields must have valid tags and bindings, following the same rules as Fill.
+// Bindings that return interfaces cannot have their fields inspected until they are resolved, unless they were made with Bind.
+// All problems found are returned together as a *ValidationError.
+func (injector *Injector) Validate() error {
+	if injector.isVerbose() {
+		injector.logCall("Validate")
+	}
+
+	var problems []error
+	visited := map[reflect.Type]bool{}
+
+	bindings := injector.visibleBindings()
+	for _, typ := range sortedTypes(bindings) {
+		for _, name := range sortedNames(bindings[typ]) {
+			b := bindings[typ][name]
+			providerType := reflect.TypeOf(b.provider)
+
+			// singletons resolve their dependencies from the injector they are registered in
+			resolver := injector
+			if b.btype == Binding_Singleton {
+				resolver = b.owner
+			}
+
+			path := []reflect.Type{typ}
+			for i := 0; i < providerType.NumIn(); i++ {
+				if providerType.In(i) == contextType {
+					continue
+				}
+				if isMultiType(providerType.In(i)) {
+					continue
+				}
+				if _, exist := resolver.lookup(providerType.In(i), ""); !exist {
+					problems = append(problems, &MissingProviderError{Type: providerType.In(i), Site: b.site, Path: path})
+				}
+			}
+
+			structType := b.filledStruct()
+			if structType == nil || visited[structType] {
+				continue
+			}
+			visited[structType] = true
+
+			problems = append(problems, resolver.validateFields(structType, b.site, path)...)
+		}
+	}
+
+	if len(problems) > 0 {
+		return injector.errorMiddleWare(&ValidationError{Errors: problems})
+	}
+	return nil
+}
+
+// validateFields checks the tagged fields of a struct type against the bindings.
+// The site is where the binding providing the struct was registered.
+func (injector *Injector) validateFields(structType reflect.Type, site string, path []reflect.Type) []error {
+	var problems []error
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		tag, tagged, valid := parseTag(field)
+		if !tagged {
+			continue
+		}
+		if !valid {
+			problems = append(problems, &InvalidTagError{Type: structType, Field: field.Name, Tag: field.Tag.Get(tagName), Path: path})
+			continue
+		}
+
+		if tag.all {
+			if !isMultiType(field.Type) {
+				problems = append(problems, &InvalidFieldError{Type: structType, Field: field.Name, Reason: "is tagged `all`, must be a slice or map of a pointer or interface type", Path: path})
+			}
+			continue
+		}
+
+		if field.Type.Kind() != reflect.Ptr && field.Type.Kind() != reflect.Interface {
+			problems = append(problems, &InvalidFieldError{Type: structType, Field: field.Name, Reason: "is not an addressable or settable field, must be a pointer or interface type", Path: path})
+			continue
+		}
+
+		if _, exist := injector.lookup(field.Type, tag.name); !exist && !tag.optional {
+			problems = append(problems, &MissingProviderError{Type: field.Type, Name: tag.name, Field: field.Name, Site: site, Path: path})
+		}
+	}
+
+	return problems
+}
+
+// sortedTypes returns the bound types in a stable order.
+func sortedTypes(bindings map[reflect.Type]map[string]*binding) []reflect.Type {
+	types := make([]reflect.Type, 0, len(bindings))
+	for typ := range bindings {
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return fullyQualifiedTypeString(types[i]) < fullyQualifiedTypeString(types[j])
+	})
+	return types
+}
+
+// sortedNames returns the binding names of a type in a stable order.
+func sortedNames(bindings map[string]*binding) []string {
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
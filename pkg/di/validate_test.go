@@ -0,0 +1,107 @@
+package di
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Invalid struct {
+	Tag     *C     `di:"invalid"`
+	Value   C      `di:"type"`
+	Missing *John  `di:"type"`
+	Named   *C     `di:"name"`
+	Ignored string `json:"ignored"`
+}
+
+func TestInjector_Validate(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {
+		assert.NoError(t, err)
+	})
+	invoked := false
+	injector.Singleton(func() *A {
+		invoked = true
+		return &A{}
+	})
+	injector.Instance(func() *B {
+		invoked = true
+		return &B{}
+	})
+	injector.Singleton(func() (*C, error) {
+		invoked = true
+		return &C{}, nil
+	})
+	injector.Singleton(func(a *A, c *C) IParent {
+		invoked = true
+		return &Parent{A: a}
+	})
+
+	assert.NoError(t, injector.Validate())
+	assert.False(t, invoked)
+}
+
+func TestInjector_Validate_Fail(t *testing.T) {
+	var injector = NewInjector()
+	injector.Singleton(func() *A {
+		return &A{}
+	})
+	injector.Singleton(func(db Database, a *A) *Invalid {
+		return &Invalid{}
+	})
+	injector.Singleton(func(db Database) *Repo {
+		return &Repo{}
+	})
+
+	err := injector.Validate()
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.ErrorIs(t, err, ErrMissingProvider)
+	assert.ErrorIs(t, err, ErrInvalidTag)
+	assert.ErrorIs(t, err, ErrInvalidField)
+
+	var validation *ValidationError
+	if assert.ErrorAs(t, err, &validation) {
+		// *A: field B, *Invalid: argument db, fields Tag, Value, Missing, Named, *Repo: argument db, field C
+		assert.Len(t, validation.Errors, 8)
+
+		var missing *MissingProviderError
+		if assert.ErrorAs(t, validation.Errors[1], &missing) {
+			assert.Equal(t, reflect.TypeFor[Database](), missing.Type)
+			assert.Equal(t, []reflect.Type{reflect.TypeFor[*Invalid]()}, missing.Path)
+		}
+		if assert.ErrorAs(t, validation.Errors[5], &missing) {
+			assert.Equal(t, "Named", missing.Name)
+			assert.Equal(t, "Named", missing.Field)
+		}
+	}
+}
+
+func TestInjector_Validate_Scope(t *testing.T) {
+	var injector = NewInjector()
+	injector.SetErrorHandler(func(err error) {})
+	injector.Instance(func(db Database) *Request {
+		return &Request{}
+	})
+	injector.Singleton(func(db Database) *John {
+		return &John{}
+	})
+
+	// the inherited bindings are checked
+	scope := injector.NewScope()
+	var validation *ValidationError
+	if assert.ErrorAs(t, scope.Validate(), &validation) {
+		assert.Len(t, validation.Errors, 2)
+	}
+
+	// dependencies bound in the scope satisfy the inherited instance bindings, but not the singletons of the parent
+	scope.Singleton(func() Database {
+		return &MySQL{}
+	})
+	if assert.ErrorAs(t, scope.Validate(), &validation) && assert.Len(t, validation.Errors, 1) {
+		var missing *MissingProviderError
+		if assert.ErrorAs(t, validation.Errors[0], &missing) {
+			assert.Equal(t, []reflect.Type{reflect.TypeFor[*John]()}, missing.Path)
+		}
+	}
+}